@@ -12,6 +12,8 @@ import (
 	setRepo "github.com/kaellybot/kaelly-encyclopedia/repositories/sets"
 	"github.com/kaellybot/kaelly-encyclopedia/repositories/weapons"
 	"github.com/kaellybot/kaelly-encyclopedia/services/almanaxes"
+	"github.com/kaellybot/kaelly-encyclopedia/services/builds"
+	"github.com/kaellybot/kaelly-encyclopedia/services/crafts"
 	"github.com/kaellybot/kaelly-encyclopedia/services/encyclopedias"
 	"github.com/kaellybot/kaelly-encyclopedia/services/equipments"
 	"github.com/kaellybot/kaelly-encyclopedia/services/news"
@@ -66,6 +68,7 @@ func New() (*Impl, error) {
 	if errSource != nil {
 		return nil, errSource
 	}
+	probes.AddStatusFuncs(sourceService.GetStatuses)
 
 	newsService := news.New(broker, sourceService)
 	almanaxService, errAlmanax := almanaxes.New(scheduler, frenchLocation,
@@ -79,8 +82,10 @@ func New() (*Impl, error) {
 		return nil, errSet
 	}
 
+	buildService := builds.New(sourceService, equipmentService, setService)
+	craftService := crafts.New(sourceService)
 	encyclopediaService := encyclopedias.New(broker, sourceService,
-		almanaxService, equipmentService, setService)
+		almanaxService, buildService, craftService, equipmentService, setService)
 
 	return &Impl{
 		broker:              broker,
@@ -0,0 +1,43 @@
+package constants
+
+import (
+	"time"
+
+	amqp "github.com/kaellybot/kaelly-amqp"
+)
+
+type TributeSort int
+
+const (
+	// TributeSortDate orders tributes by the first date they are needed.
+	TributeSortDate TributeSort = iota
+	// TributeSortQuantity orders tributes by total quantity, biggest first.
+	TributeSortQuantity
+)
+
+// TributeShoppingList gathers the tributes needed over a period, one per item.
+type TributeShoppingList struct {
+	Tributes []Tribute
+	// Reward is the total kamas earned by completing every almanax of the period.
+	Reward int64
+}
+
+type Tribute struct {
+	ItemID   string
+	ItemName string
+	ItemIcon string
+	ItemType amqp.ItemType
+	// Quantity sums the quantities of every day the item is needed.
+	Quantity int64
+	Dates    []time.Time
+}
+
+// TributeAlmanaxes are the next almanax days requiring an item as tribute, paginated.
+type TributeAlmanaxes struct {
+	ItemID    string
+	ItemName  string
+	Almanaxes []*amqp.Almanax
+	Offset    int64
+	// Total is the number of days requiring the item over the next year, regardless of offset and size.
+	Total int64
+}
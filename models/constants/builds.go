@@ -0,0 +1,87 @@
+package constants
+
+import (
+	"image"
+
+	amqp "github.com/kaellybot/kaelly-amqp"
+)
+
+const (
+	buildFifthCell = setFourthCell + setItemSizePx + setItemMarginPx
+	buildSixthCell = buildFifthCell + setItemSizePx + setItemMarginPx
+)
+
+type BuildErrorType int
+
+const (
+	// BuildErrorUnknownSlot is raised for items which cannot be equipped.
+	BuildErrorUnknownSlot BuildErrorType = iota
+	// BuildErrorSlotFull is raised when every slot of the item type is already taken.
+	BuildErrorSlotFull
+	// BuildErrorDuplicate is raised when the same item is equipped twice, such as two identical rings or Dofus.
+	BuildErrorDuplicate
+	// BuildErrorLevelTooHigh is raised when the item level exceeds the character level.
+	BuildErrorLevelTooHigh
+	// BuildErrorConditionNotMet is raised when the item conditions are not met by the build characteristics.
+	BuildErrorConditionNotMet
+	// BuildErrorMissing is raised when the item cannot be retrieved.
+	BuildErrorMissing
+)
+
+// BuildRequest describes a loadout: one item per slot, for a character.
+type BuildRequest struct {
+	// Level is the character level, ignored if not positive.
+	Level int64
+	// Alignment is the character alignment ID, see CharacterProfile.
+	Alignment int64
+	// Characteristics are the base characteristics, added to the ones given by the loadout.
+	Characteristics []Characteristic
+	EquipmentIDs    []int64
+}
+
+type Build struct {
+	Equipments []BuildEquipment
+	Sets       []SetBonus
+	// Characteristics total base characteristics, equipments and set bonuses.
+	Characteristics []Characteristic
+	Errors          []BuildError
+}
+
+type BuildEquipment struct {
+	ID    string
+	Name  string
+	Type  amqp.EquipmentType
+	Level int64
+	Slot  image.Point
+}
+
+// BuildError is a slot rule or a condition an item breaks, or an item which cannot be retrieved.
+// Items raising slot or missing errors are left out of the build.
+type BuildError struct {
+	Type        BuildErrorType
+	EquipmentID string
+	// Conditions are the unmet conditions, for BuildErrorConditionNotMet only.
+	Conditions []Condition
+}
+
+// GetBuildPoints returns the slots of a loadout; it extends the set layout with mounts and Dofus slots.
+// Equipment types sharing a point cannot be equipped together.
+func GetBuildPoints() map[amqp.EquipmentType][]image.Point {
+	points := GetSetPoints()
+	points[amqp.EquipmentType_MOUNT] = points[amqp.EquipmentType_PET]
+	points[amqp.EquipmentType_PETSMOUNT] = points[amqp.EquipmentType_PET]
+
+	dofusPoints := []image.Point{
+		image.Pt(setFirstCell, buildFifthCell),
+		image.Pt(setSecondCell, buildFifthCell),
+		image.Pt(setThirdCell, buildFifthCell),
+		image.Pt(setFirstCell, buildSixthCell),
+		image.Pt(setSecondCell, buildSixthCell),
+		image.Pt(setThirdCell, buildSixthCell),
+	}
+	points[amqp.EquipmentType_DOFUS] = dofusPoints
+	points[amqp.EquipmentType_TROPHY] = dofusPoints
+	points[amqp.EquipmentType_PRYSMARADITE] = dofusPoints
+
+	return points
+}
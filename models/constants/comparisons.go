@@ -0,0 +1,55 @@
+package constants
+
+import amqp "github.com/kaellybot/kaelly-amqp"
+
+const (
+	MinComparedEquipments = 2
+	MaxComparedEquipments = 4
+)
+
+// EquipmentComparison compares equipments side by side.
+// Deltas are computed against the first compared equipment.
+type EquipmentComparison struct {
+	Equipments    []ComparedEquipment
+	WeaponEffects []EffectComparison
+	Effects       []EffectComparison
+}
+
+type ComparedEquipment struct {
+	ID              string
+	Name            string
+	Type            amqp.EquipmentType
+	Level           int64
+	LevelDelta      int64
+	Characteristics *amqp.EncyclopediaItemAnswer_Equipment_Characteristics
+	// CharacteristicDeltas is nil unless both this equipment and the first one are weapons.
+	CharacteristicDeltas *WeaponCharacteristicDeltas
+	Conditions           *amqp.EncyclopediaItemAnswer_Conditions
+	// ConditionReport is nil unless a character profile is given.
+	ConditionReport *ConditionReport
+}
+
+type WeaponCharacteristicDeltas struct {
+	Cost           int64
+	MinRange       int64
+	MaxRange       int64
+	MaxCastPerTurn int64
+	CriticalRate   int64
+	CriticalBonus  int64
+}
+
+// EffectComparison matches an effect type across the compared equipments.
+// Values and Deltas are ordered as the compared equipments; an absent effect is worth zero.
+type EffectComparison struct {
+	EffectID int32
+	Name     string
+	Key      string
+	Element  EffectElement
+	Values   []EffectRange
+	Deltas   []EffectRange
+}
+
+type EffectRange struct {
+	Min int64
+	Max int64
+}
@@ -0,0 +1,57 @@
+package constants
+
+const (
+	ConditionOperatorGreater  = ">"
+	ConditionOperatorLower    = "<"
+	ConditionOperatorEqual    = "="
+	ConditionOperatorNotEqual = "!"
+	ConditionRelationAnd      = "and"
+	ConditionRelationOr       = "or"
+)
+
+// CharacterProfile describes a character against which item conditions are evaluated.
+type CharacterProfile struct {
+	// Level is ignored if not positive: level conditions are left unchecked.
+	Level int64
+	// Alignment is the alignment ID as found in conditions: 0 for neutral, 1 for Bonta, 2 for Brakmar.
+	Alignment       int64
+	Characteristics []Characteristic
+}
+
+// Condition is a leaf of an item conditions tree.
+type Condition struct {
+	ElementID   string
+	ElementName string
+	Operator    string
+	Value       int64
+}
+
+type ConditionReport struct {
+	Equippable bool
+	// Failures are the leaf conditions which make the item not equippable.
+	Failures []Condition
+	// Unchecked are the leaf conditions the profile does not describe; they are considered met.
+	Unchecked []Condition
+}
+
+// GetLevelElementNames returns the lowercased name of the level condition element, by DofusDude language.
+func GetLevelElementNames() map[string]string {
+	return map[string]string{
+		"fr": "niveau",
+		"en": "level",
+		"es": "nivel",
+		"de": "stufe",
+		"pt": "nível",
+	}
+}
+
+// GetAlignmentElementNames returns the lowercased name of the alignment condition element, by DofusDude language.
+func GetAlignmentElementNames() map[string]string {
+	return map[string]string{
+		"fr": "alignement",
+		"en": "alignment",
+		"es": "alineamiento",
+		"de": "gesinnung",
+		"pt": "alinhamento",
+	}
+}
@@ -33,21 +33,99 @@ const (
 	// Redis password.
 	RedisPassword = "REDIS_PASSWORD"
 
-	// Redis cache retention. Duration type.
+	// In-memory cache retention, in front of Redis. Duration type.
 	RedisCacheRetention = "REDIS_CACHE_RETENTION"
 
+	// Duration during which expired cache entries are still served while being refreshed. Duration type.
+	RedisCacheStaleRetention = "REDIS_CACHE_STALE_RETENTION"
+
+	// Cache retention of almanax days, after which they are refreshed. Duration type.
+	AlmanaxCacheRetention = "ALMANAX_CACHE_RETENTION"
+
+	// Cache retention of almanax ranges, after which they are refreshed. Duration type.
+	AlmanaxRangeCacheRetention = "ALMANAX_RANGE_CACHE_RETENTION"
+
+	// Cache retention of almanax effect searches, after which they are refreshed. Duration type.
+	AlmanaxEffectCacheRetention = "ALMANAX_EFFECT_CACHE_RETENTION"
+
+	// Cache retention of items and item searches, after which they are refreshed. Duration type.
+	ItemCacheRetention = "ITEM_CACHE_RETENTION"
+
+	// Cache retention of sets and set searches, after which they are refreshed. Duration type.
+	SetCacheRetention = "SET_CACHE_RETENTION"
+
+	// Cache retention of not found results. Duration type.
+	NotFoundCacheRetention = "NOT_FOUND_CACHE_RETENTION"
+
 	// Redis cache size, following LFU rules.
 	RedisCacheSize = "REDIS_CACHE_SIZE"
 
 	// Cron tab to send almanax news.
 	AlmanaxCronTab = "ALMANAX_CRON_TAB"
 
+	// Cron tab to prewarm cache with next almanax days and popular items.
+	PrewarmCronTab = "PREWARM_CRON_TAB"
+
+	// Number of almanax days to prewarm, starting tomorrow.
+	PrewarmAlmanaxDays = "PREWARM_ALMANAX_DAYS"
+
+	// Number of the most requested items to prewarm.
+	PrewarmItemCount = "PREWARM_ITEM_COUNT"
+
 	// Cron tab to update set icons.
 	UpdateSetCronTab = "UPDATE_SET_CRON_TAB"
 
 	// Timeout to retrieve Dofus data. Duration type.
 	DofusDudeTimeout = "HTTP_TIMEOUT"
 
+	// Maximum DofusDude requests per second; rate limiter is disabled if not positive.
+	DofusDudeRateLimit = "HTTP_RATE_LIMIT"
+
+	// Number of DofusDude requests allowed in a burst.
+	DofusDudeRateBurst = "HTTP_RATE_BURST"
+
+	// Maximum wait for the rate limiter before failing as upstream busy. Duration type.
+	DofusDudeRateMaxWait = "HTTP_RATE_MAX_WAIT"
+
+	// Timeout to retrieve full catalogs used by autocomplete indexes. Duration type.
+	IndexTimeout = "INDEX_TIMEOUT"
+
+	// Maximum recipe depth resolved by crafting trees; deeper ingredients are considered as raw resources.
+	CraftMaxDepth = "CRAFT_MAX_DEPTH"
+
+	// Number of retries on DofusDude 5xx responses and timeouts.
+	DofusDudeRetries = "HTTP_RETRIES"
+
+	// Initial delay between two retries, doubled at each attempt and jittered. Duration type.
+	DofusDudeRetryBackoff = "HTTP_RETRY_BACKOFF"
+
+	// Maximum delay between two retries. Duration type.
+	DofusDudeRetryMaxBackoff = "HTTP_RETRY_MAX_BACKOFF"
+
+	// Consecutive DofusDude failures before failing fast.
+	CircuitBreakerThreshold = "CIRCUIT_BREAKER_THRESHOLD"
+
+	// Duration during which calls fail fast before DofusDude is probed again. Duration type.
+	CircuitBreakerCooldown = "CIRCUIT_BREAKER_COOLDOWN"
+
+	// Directory containing DofusDude JSON exports; snapshot source is disabled if empty.
+	SnapshotDirectory = "SNAPSHOT_DIRECTORY"
+
+	// Comma-separated sources used to retrieve almanax days, by order of priority.
+	AlmanaxSources = "ALMANAX_SOURCES"
+
+	// Comma-separated sources used to retrieve almanax ranges, by order of priority.
+	AlmanaxRangeSources = "ALMANAX_RANGE_SOURCES"
+
+	// Comma-separated sources used to retrieve almanax effects, by order of priority.
+	AlmanaxEffectSources = "ALMANAX_EFFECT_SOURCES"
+
+	// Comma-separated sources used to retrieve items, by order of priority.
+	ItemSources = "ITEM_SOURCES"
+
+	// Comma-separated sources used to retrieve sets, by order of priority.
+	SetSources = "SET_SOURCES"
+
 	// Probe port.
 	ProbePort = "PROBE_PORT"
 
@@ -60,43 +138,95 @@ const (
 	// Boolean; used to register commands at development guild level or globally.
 	Production = "PRODUCTION"
 
-	defaultMySQLURL            = "localhost:3306"
-	defaultMySQLUser           = ""
-	defaultMySQLPassword       = ""
-	defaultMySQLDatabase       = "kaellybot"
-	defaultRabbitMQAddress     = "amqp://localhost:5672"
-	defaultRedisURL            = "localhost:6379"
-	defaultRedisUser           = ""
-	defaultRedisPassword       = ""
-	defaultRedisCacheRetention = 60 * time.Minute
-	defaultRedisCacheSize      = 1024
-	defaultAlmanaxCronTab      = "1 0 0 * * *"
-	defaultUpdateSetCronTab    = "0 0 2 * * *"
-	defaultDofusDudeTimeout    = 10 * time.Second
-	defaultProbePort           = 9090
-	defaultMetricPort          = 2112
-	defaultLogLevel            = zerolog.InfoLevel
-	defaultProduction          = false
+	defaultMySQLURL                    = "localhost:3306"
+	defaultMySQLUser                   = ""
+	defaultMySQLPassword               = ""
+	defaultMySQLDatabase               = "kaellybot"
+	defaultRabbitMQAddress             = "amqp://localhost:5672"
+	defaultRedisURL                    = "localhost:6379"
+	defaultRedisUser                   = ""
+	defaultRedisPassword               = ""
+	defaultRedisCacheRetention         = 60 * time.Minute
+	defaultRedisStaleRetention         = 24 * time.Hour
+	defaultAlmanaxCacheRetention       = 30 * 24 * time.Hour
+	defaultAlmanaxRangeCacheRetention  = 24 * time.Hour
+	defaultAlmanaxEffectCacheRetention = 6 * time.Hour
+	defaultItemCacheRetention          = 24 * time.Hour
+	defaultSetCacheRetention           = 24 * time.Hour
+	defaultNotFoundCacheRetention      = 5 * time.Minute
+	defaultRedisCacheSize              = 1024
+	defaultAlmanaxCronTab              = "1 0 0 * * *"
+	defaultPrewarmCronTab              = "0 45 23 * * *"
+	defaultPrewarmAlmanaxDays          = 7
+	defaultPrewarmItemCount            = 100
+	defaultUpdateSetCronTab            = "0 0 2 * * *"
+	defaultDofusDudeTimeout            = 10 * time.Second
+	defaultIndexTimeout                = 2 * time.Minute
+	defaultDofusDudeRateLimit          = 10.0
+	defaultDofusDudeRateBurst          = 20
+	defaultDofusDudeRateMaxWait        = 2 * time.Second
+	defaultCraftMaxDepth               = 10
+	defaultDofusDudeRetries            = 2
+	defaultRetryBackoff                = 200 * time.Millisecond
+	defaultRetryMaxBackoff             = 2 * time.Second
+	defaultBreakerThreshold            = 5
+	defaultBreakerCooldown             = 30 * time.Second
+	defaultSnapshotDirectory           = ""
+	defaultAlmanaxSources              = DofusDudeSourceName
+	defaultAlmanaxRangeSources         = DofusDudeSourceName
+	defaultAlmanaxEffectSources        = DofusDudeSourceName
+	defaultItemSources                 = DofusDudeSourceName
+	defaultSetSources                  = DofusDudeSourceName
+	defaultProbePort                   = 9090
+	defaultMetricPort                  = 2112
+	defaultLogLevel                    = zerolog.InfoLevel
+	defaultProduction                  = false
 )
 
 func GetDefaultConfigValues() map[string]any {
 	return map[string]any{
-		MySQLURL:            defaultMySQLURL,
-		MySQLUser:           defaultMySQLUser,
-		MySQLPassword:       defaultMySQLPassword,
-		MySQLDatabase:       defaultMySQLDatabase,
-		RabbitMQAddress:     defaultRabbitMQAddress,
-		RedisURL:            defaultRedisURL,
-		RedisUser:           defaultRedisUser,
-		RedisPassword:       defaultRedisPassword,
-		RedisCacheRetention: defaultRedisCacheRetention,
-		RedisCacheSize:      defaultRedisCacheSize,
-		AlmanaxCronTab:      defaultAlmanaxCronTab,
-		UpdateSetCronTab:    defaultUpdateSetCronTab,
-		DofusDudeTimeout:    defaultDofusDudeTimeout,
-		ProbePort:           defaultProbePort,
-		MetricPort:          defaultMetricPort,
-		LogLevel:            defaultLogLevel.String(),
-		Production:          defaultProduction,
+		MySQLURL:                    defaultMySQLURL,
+		MySQLUser:                   defaultMySQLUser,
+		MySQLPassword:               defaultMySQLPassword,
+		MySQLDatabase:               defaultMySQLDatabase,
+		RabbitMQAddress:             defaultRabbitMQAddress,
+		RedisURL:                    defaultRedisURL,
+		RedisUser:                   defaultRedisUser,
+		RedisPassword:               defaultRedisPassword,
+		RedisCacheRetention:         defaultRedisCacheRetention,
+		RedisCacheStaleRetention:    defaultRedisStaleRetention,
+		AlmanaxCacheRetention:       defaultAlmanaxCacheRetention,
+		AlmanaxRangeCacheRetention:  defaultAlmanaxRangeCacheRetention,
+		AlmanaxEffectCacheRetention: defaultAlmanaxEffectCacheRetention,
+		ItemCacheRetention:          defaultItemCacheRetention,
+		SetCacheRetention:           defaultSetCacheRetention,
+		NotFoundCacheRetention:      defaultNotFoundCacheRetention,
+		RedisCacheSize:              defaultRedisCacheSize,
+		AlmanaxCronTab:              defaultAlmanaxCronTab,
+		PrewarmCronTab:              defaultPrewarmCronTab,
+		PrewarmAlmanaxDays:          defaultPrewarmAlmanaxDays,
+		PrewarmItemCount:            defaultPrewarmItemCount,
+		UpdateSetCronTab:            defaultUpdateSetCronTab,
+		DofusDudeTimeout:            defaultDofusDudeTimeout,
+		IndexTimeout:                defaultIndexTimeout,
+		DofusDudeRateLimit:          defaultDofusDudeRateLimit,
+		DofusDudeRateBurst:          defaultDofusDudeRateBurst,
+		DofusDudeRateMaxWait:        defaultDofusDudeRateMaxWait,
+		CraftMaxDepth:               defaultCraftMaxDepth,
+		DofusDudeRetries:            defaultDofusDudeRetries,
+		DofusDudeRetryBackoff:       defaultRetryBackoff,
+		DofusDudeRetryMaxBackoff:    defaultRetryMaxBackoff,
+		CircuitBreakerThreshold:     defaultBreakerThreshold,
+		CircuitBreakerCooldown:      defaultBreakerCooldown,
+		SnapshotDirectory:           defaultSnapshotDirectory,
+		AlmanaxSources:              defaultAlmanaxSources,
+		AlmanaxRangeSources:         defaultAlmanaxRangeSources,
+		AlmanaxEffectSources:        defaultAlmanaxEffectSources,
+		ItemSources:                 defaultItemSources,
+		SetSources:                  defaultSetSources,
+		ProbePort:                   defaultProbePort,
+		MetricPort:                  defaultMetricPort,
+		LogLevel:                    defaultLogLevel.String(),
+		Production:                  defaultProduction,
 	}
 }
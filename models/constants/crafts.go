@@ -0,0 +1,46 @@
+package constants
+
+import amqp "github.com/kaellybot/kaelly-amqp"
+
+// CraftNode is an ingredient needed to craft its parent, in the required quantity.
+// Its children are the ingredients of its own recipe, if it has one and it has been resolved.
+type CraftNode struct {
+	Ingredient Ingredient
+	Quantity   int64
+	Children   []*CraftNode
+	// Cyclic is true when the ingredient is one of its own ancestors: its recipe is not resolved.
+	Cyclic bool
+	// Truncated is true when the depth limit is reached: its recipe is not resolved.
+	Truncated bool
+	// Unresolved is true when the ingredient cannot be retrieved: only its ID is known.
+	Unresolved bool
+}
+
+type RawResource struct {
+	Ingredient Ingredient
+	Quantity   int64
+}
+
+type CraftTree struct {
+	Root         *CraftNode
+	RawResources []RawResource
+	// UnresolvedResources totals the cyclic, truncated and unresolved nodes, whose recipe is unknown:
+	// they are not raw resources, their own ingredients are missing from RawResources.
+	UnresolvedResources []RawResource
+}
+
+// ItemUsage is an item whose recipe uses an ingredient, in the given quantity.
+type ItemUsage struct {
+	ID       string
+	Name     string
+	Type     amqp.ItemType
+	Level    int64
+	Quantity int64
+}
+
+type ItemUsages struct {
+	Usages []ItemUsage
+	Offset int64
+	// Total is the number of usages within the level bounds, regardless of offset and size.
+	Total int64
+}
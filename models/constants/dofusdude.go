@@ -8,9 +8,59 @@ const (
 	DofusDudeAlmanaxDateFormat = "2006-01-02"
 	DofusDudeAlmanaxSizeLimit  = 35
 	DofusDudeLimit             = 25
+	DofusDudeSortAscending     = "asc"
+
+	DefaultGame = amqp.Game_DOFUS_GAME
 )
 
+// GetDofusDudeGames returns the DofusDude game of each supported game;
+// Dofus Touch and Dofus Retro are not served by DofusDude.
+func GetDofusDudeGames() map[amqp.Game]string {
+	return map[amqp.Game]string{
+		amqp.Game_DOFUS_GAME: DofusDudeGame,
+	}
+}
+
+// GetDofusDudeEquipmentTypes returns the DofusDude type name of each equipment type,
+// used to filter equipment lists upstream; mounts are not listed as equipments.
+func GetDofusDudeEquipmentTypes() map[amqp.EquipmentType]string {
+	return map[amqp.EquipmentType]string{
+		amqp.EquipmentType_HAT:          "hat",
+		amqp.EquipmentType_CLOAK:        "cloak",
+		amqp.EquipmentType_AXE:          "axe",
+		amqp.EquipmentType_BOW:          "bow",
+		amqp.EquipmentType_DAGGER:       "dagger",
+		amqp.EquipmentType_HAMMER:       "hammer",
+		amqp.EquipmentType_LANCE:        "lance",
+		amqp.EquipmentType_PICKAXE:      "pickaxe",
+		amqp.EquipmentType_SCYTHE:       "scythe",
+		amqp.EquipmentType_SHOVEL:       "shovel",
+		amqp.EquipmentType_STAFF:        "staff",
+		amqp.EquipmentType_SWORD:        "sword",
+		amqp.EquipmentType_WAND:         "wand",
+		amqp.EquipmentType_SHIELD:       "shield",
+		amqp.EquipmentType_PET:          "pet",
+		amqp.EquipmentType_AMULET:       "amulet",
+		amqp.EquipmentType_RING:         "ring",
+		amqp.EquipmentType_BELT:         "belt",
+		amqp.EquipmentType_BOOT:         "boots",
+		amqp.EquipmentType_DOFUS:        "dofus",
+		amqp.EquipmentType_TROPHY:       "trophy",
+		amqp.EquipmentType_PRYSMARADITE: "prysmaradite",
+		amqp.EquipmentType_PETSMOUNT:    "petsmount",
+	}
+}
+
 func GetSupportedSearchIndex() []string {
+	return append(GetTypeFilteredSearchIndex(),
+		"items-consumables",
+		"items-quest_items",
+		"items-resources",
+	)
+}
+
+// GetTypeFilteredSearchIndex returns the search indexes restricted to GetSupportedTypeEnums.
+func GetTypeFilteredSearchIndex() []string {
 	return []string{
 		"items-cosmetics",
 		"items-equipment",
@@ -18,6 +68,11 @@ func GetSupportedSearchIndex() []string {
 	}
 }
 
+// GetSearchItemFields returns the item fields added to search results, so that lists can show them.
+func GetSearchItemFields() []string {
+	return []string{"level", "image_urls"}
+}
+
 func GetSupportedTypeEnums() []string {
 	return []string{
 		// Equipments
@@ -75,11 +130,3 @@ func GetLanguages() map[amqp.Language]string {
 		amqp.Language_PT:  "pt",
 	}
 }
-
-func GetDofusDudeSource() *amqp.Source {
-	return &amqp.Source{
-		Name: GetEncyclopediasSource().Name,
-		Icon: GetEncyclopediasSource().Icon,
-		Url:  GetEncyclopediasSource().URL,
-	}
-}
@@ -0,0 +1,129 @@
+package constants
+
+type EffectCategory int
+
+const (
+	EffectCategoryCharacteristic EffectCategory = iota
+	EffectCategoryDamage
+	EffectCategoryHeal
+	EffectCategorySteal
+	// EffectCategoryOther holds the remaining effects, such as titles, emotes or unknown weapon lines.
+	EffectCategoryOther
+)
+
+type EffectElement int
+
+const (
+	EffectElementNone EffectElement = iota
+	EffectElementNeutral
+	EffectElementEarth
+	EffectElementFire
+	EffectElementWater
+	EffectElementAir
+)
+
+// Effect is the structured form of a DofusDude effect, alongside its formatted label.
+type Effect struct {
+	ID    string
+	Label string
+	// Min and Max are signed: maluses are negative. A single value is returned as both bounds.
+	Min      int64
+	Max      int64
+	Element  EffectElement
+	Category EffectCategory
+	// Key is the normalized characteristic, empty if unknown.
+	Key string
+	// IsWeaponLine is true for the lines applied when hitting with a weapon.
+	IsWeaponLine bool
+}
+
+// EffectDefinition normalizes an effect type.
+type EffectDefinition struct {
+	Key      string
+	Category EffectCategory
+	Element  EffectElement
+	// Negative is true for maluses: their values are subtracted from the characteristic.
+	Negative bool
+}
+
+// GetEffectDefinitions returns the known effect types, by DofusDude effect type ID.
+func GetEffectDefinitions() map[int32]EffectDefinition {
+	return map[int32]EffectDefinition{
+		// Weapon lines
+		91:  {Category: EffectCategorySteal, Element: EffectElementWater},
+		92:  {Category: EffectCategorySteal, Element: EffectElementEarth},
+		93:  {Category: EffectCategorySteal, Element: EffectElementAir},
+		94:  {Category: EffectCategorySteal, Element: EffectElementFire},
+		95:  {Category: EffectCategorySteal, Element: EffectElementNeutral},
+		96:  {Category: EffectCategoryDamage, Element: EffectElementWater},
+		97:  {Category: EffectCategoryDamage, Element: EffectElementEarth},
+		98:  {Category: EffectCategoryDamage, Element: EffectElementAir},
+		99:  {Category: EffectCategoryDamage, Element: EffectElementFire},
+		100: {Category: EffectCategoryDamage, Element: EffectElementNeutral},
+		108: {Category: EffectCategoryHeal},
+
+		// Characteristics
+		111: {Key: "ap"},
+		128: {Key: "mp"},
+		117: {Key: "range"},
+		125: {Key: "vitality"},
+		124: {Key: "wisdom"},
+		118: {Key: "strength", Element: EffectElementEarth},
+		126: {Key: "intelligence", Element: EffectElementFire},
+		123: {Key: "chance", Element: EffectElementWater},
+		119: {Key: "agility", Element: EffectElementAir},
+		138: {Key: "power"},
+		112: {Key: "damage"},
+		115: {Key: "critical"},
+		178: {Key: "heals"},
+		182: {Key: "summons"},
+		174: {Key: "initiative"},
+		176: {Key: "prospecting"},
+		158: {Key: "pods"},
+		752: {Key: "dodge"},
+		753: {Key: "lock"},
+		160: {Key: "apParry"},
+		161: {Key: "mpParry"},
+		410: {Key: "apReduction"},
+		412: {Key: "mpReduction"},
+		414: {Key: "pushbackDamage"},
+		416: {Key: "pushbackResistance"},
+		418: {Key: "criticalDamage"},
+		420: {Key: "criticalResistance"},
+		220: {Key: "reflect"},
+		225: {Key: "trapDamage"},
+		226: {Key: "trapPower"},
+		422: {Key: "earthDamage", Element: EffectElementEarth},
+		424: {Key: "fireDamage", Element: EffectElementFire},
+		426: {Key: "waterDamage", Element: EffectElementWater},
+		428: {Key: "airDamage", Element: EffectElementAir},
+		430: {Key: "neutralDamage", Element: EffectElementNeutral},
+		210: {Key: "earthResistancePercent", Element: EffectElementEarth},
+		211: {Key: "waterResistancePercent", Element: EffectElementWater},
+		212: {Key: "airResistancePercent", Element: EffectElementAir},
+		213: {Key: "fireResistancePercent", Element: EffectElementFire},
+		214: {Key: "neutralResistancePercent", Element: EffectElementNeutral},
+		240: {Key: "earthResistance", Element: EffectElementEarth},
+		241: {Key: "waterResistance", Element: EffectElementWater},
+		242: {Key: "airResistance", Element: EffectElementAir},
+		243: {Key: "fireResistance", Element: EffectElementFire},
+		244: {Key: "neutralResistance", Element: EffectElementNeutral},
+
+		// Maluses
+		168: {Key: "ap", Negative: true},
+		169: {Key: "mp", Negative: true},
+		116: {Key: "range", Negative: true},
+		153: {Key: "vitality", Negative: true},
+		156: {Key: "wisdom", Negative: true},
+		157: {Key: "strength", Element: EffectElementEarth, Negative: true},
+		155: {Key: "intelligence", Element: EffectElementFire, Negative: true},
+		152: {Key: "chance", Element: EffectElementWater, Negative: true},
+		154: {Key: "agility", Element: EffectElementAir, Negative: true},
+		145: {Key: "damage", Negative: true},
+		171: {Key: "critical", Negative: true},
+		179: {Key: "heals", Negative: true},
+		175: {Key: "initiative", Negative: true},
+		177: {Key: "prospecting", Negative: true},
+		159: {Key: "pods", Negative: true},
+	}
+}
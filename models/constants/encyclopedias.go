@@ -1,15 +1,31 @@
 package constants
 
+const (
+	DofusDudeSourceName = "dofusdude"
+	SnapshotSourceName  = "snapshot"
+)
+
 type Source struct {
 	Name string
 	Icon string
 	URL  string
+	// Stale is true when answers rely on cached data past its soft expiry.
+	Stale bool
 }
 
-func GetEncyclopediasSource() Source {
+func GetDofusDudeSource() Source {
 	return Source{
-		Name: "dofusdude",
+		Name: DofusDudeSourceName,
 		Icon: "https://avatars.githubusercontent.com/u/82651571",
 		URL:  "https://github.com/dofusdude",
 	}
 }
+
+// GetSnapshotSource describes local DofusDude data dumps.
+func GetSnapshotSource() Source {
+	return Source{
+		Name: SnapshotSourceName,
+		Icon: GetDofusDudeSource().Icon,
+		URL:  GetDofusDudeSource().URL,
+	}
+}
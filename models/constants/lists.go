@@ -0,0 +1,52 @@
+package constants
+
+import amqp "github.com/kaellybot/kaelly-amqp"
+
+type ListSort int
+
+const (
+	// ListSortRelevance keeps the best matches first.
+	ListSortRelevance ListSort = iota
+	ListSortName
+	ListSortLevel
+)
+
+// ListFilter describes the entries to return for a list request; zero values do not filter.
+type ListFilter struct {
+	Offset int64
+	// Size is the maximum number of entries, every entry is returned if not positive.
+	Size int64
+	// ItemType filters entries by type, amqp.ItemType_ANY_ITEM_TYPE does not filter.
+	ItemType amqp.ItemType
+	// MinLevel and MaxLevel filter entries by level, ignored if not positive.
+	// Entries without level are left out as soon as a level bound is set.
+	MinLevel   int64
+	MaxLevel   int64
+	Sort       ListSort
+	Descending bool
+}
+
+type ListEntry struct {
+	ID   string
+	Name string
+	Type amqp.ItemType
+	// Level is 0 for entries without level, such as mounts or almanax effects.
+	Level int64
+	Icon  string
+}
+
+type List struct {
+	Entries []ListEntry
+	Offset  int64
+	// Total is the number of entries matching the filter, regardless of offset and size.
+	Total int64
+}
+
+// GetDefaultListFilter returns the filter applied to autocompletion: the best matches only.
+func GetDefaultListFilter() ListFilter {
+	return ListFilter{
+		Size:     DofusDudeLimit,
+		ItemType: amqp.ItemType_ANY_ITEM_TYPE,
+		Sort:     ListSortRelevance,
+	}
+}
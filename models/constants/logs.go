@@ -14,6 +14,7 @@ const (
 	LogQueryID       = "queryID"
 	LogQueryType     = "queryType"
 	LogReplyTo       = "replyTo"
+	LogSource        = "source"
 
 	LogLevelFallback = zerolog.InfoLevel
 )
@@ -0,0 +1,6 @@
+package constants
+
+const (
+	MetricNamespace   = "kaelly_encyclopedia"
+	MetricLabelSource = "source"
+)
@@ -0,0 +1,47 @@
+package constants
+
+import amqp "github.com/kaellybot/kaelly-amqp"
+
+type EquipmentSort int
+
+const (
+	EquipmentSortLevel EquipmentSort = iota
+	EquipmentSortName
+	// EquipmentSortEffectValue sorts by the maximum value of the filtered effect.
+	EquipmentSortEffectValue
+)
+
+// EquipmentFilter describes an equipment search; zero values do not filter.
+type EquipmentFilter struct {
+	EquipmentType amqp.EquipmentType
+	MinLevel      int64
+	MaxLevel      int64
+	// EffectID is the effect type to filter on, see Characteristic.
+	EffectID int32
+	// MinValue is compared to the effect maximum and MaxValue to its minimum, if set:
+	// an equipment matches as soon as one of its rolls does.
+	MinValue   *int64
+	MaxValue   *int64
+	Sort       EquipmentSort
+	Descending bool
+	Offset     int64
+	// Size is the maximum number of equipments, every equipment is returned if not positive.
+	Size int64
+}
+
+type EquipmentSearchResult struct {
+	ID    string
+	Name  string
+	Type  amqp.EquipmentType
+	Level int64
+	Icon  string
+	// Effect is the filtered effect, nil if no effect is filtered.
+	Effect *Characteristic
+}
+
+type EquipmentSearch struct {
+	Results []EquipmentSearchResult
+	Offset  int64
+	// Total is the number of equipments matching the filter, regardless of offset and size.
+	Total int64
+}
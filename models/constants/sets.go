@@ -45,3 +45,31 @@ func GetSetPoints() map[amqp.EquipmentType][]image.Point {
 		amqp.EquipmentType_BOOT: {image.Pt(setSecondCell, setFourthCell)},
 	}
 }
+
+// Characteristic is the total of effects sharing the same effect type, as a range.
+type Characteristic struct {
+	EffectID int32
+	Name     string
+	// Key is the normalized characteristic, empty if unknown.
+	Key     string
+	Element EffectElement
+	Min     int64
+	Max     int64
+}
+
+// SetBonus describes a partially equipped set.
+type SetBonus struct {
+	SetID   int64
+	SetName string
+	// EquippedIDs are the equipped items which belong to the set.
+	EquippedIDs []int64
+	// ItemNumber is the active bonus tier, 0 if no bonus is active.
+	ItemNumber int64
+	Bonus      []Characteristic
+	// NextItemNumber is the next bonus tier, 0 if the active one is the last.
+	NextItemNumber int64
+	// NextGains are the characteristics gained by reaching the next bonus tier.
+	NextGains []Characteristic
+	// Characteristics total the equipped items and the active bonus.
+	Characteristics []Characteristic
+}
@@ -1,7 +1,8 @@
 package entities
 
 type Almanax struct {
-	Day               int `gorm:"primaryKey"`
-	Month             int `gorm:"primaryKey"`
-	DofusDudeEffectID string
+	Day                int `gorm:"primaryKey"`
+	Month              int `gorm:"primaryKey"`
+	DofusDudeEffectID  string
+	DofusDudeTributeID int32
 }
@@ -1,7 +1,9 @@
 package mappers
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/dofusdude/dodugo"
@@ -13,8 +15,8 @@ import (
 )
 
 func MapAlmanaxEffects(request *amqp.EncyclopediaAlmanaxEffectRequest, effectName string,
-	dodugoAlmanaxes []*dodugo.Almanax, total int64, sourceService sources.Service,
-	language amqp.Language) *amqp.RabbitMQMessage {
+	dodugoAlmanaxes []*dodugo.Almanax, total int64, source constants.Source,
+	sourceService sources.Service, language amqp.Language) *amqp.RabbitMQMessage {
 	if effectName == "" {
 		return &amqp.RabbitMQMessage{
 			Type:     amqp.RabbitMQMessage_ENCYCLOPEDIA_ALMANAX_EFFECT_ANSWER,
@@ -22,7 +24,7 @@ func MapAlmanaxEffects(request *amqp.EncyclopediaAlmanaxEffectRequest, effectNam
 			Language: language,
 			EncyclopediaAlmanaxEffectAnswer: &amqp.EncyclopediaAlmanaxEffectAnswer{
 				Query:  request.GetQuery(),
-				Source: constants.GetDofusDudeSource(),
+				Source: MapSource(source),
 			},
 		}
 	}
@@ -54,38 +56,73 @@ func MapAlmanaxEffects(request *amqp.EncyclopediaAlmanaxEffectRequest, effectNam
 			Page:       page,
 			Pages:      pages,
 			Total:      total,
-			Source:     constants.GetDofusDudeSource(),
+			Source:     MapSource(source),
 		},
 	}
 }
 
-func MapAlmanaxAnswer(dodugoAlmanax *dodugo.Almanax, sourceService sources.Service,
-	language amqp.Language) *amqp.RabbitMQMessage {
+func MapAlmanaxAnswer(dodugoAlmanax *dodugo.Almanax, source constants.Source,
+	sourceService sources.Service, language amqp.Language) *amqp.RabbitMQMessage {
 	return &amqp.RabbitMQMessage{
 		Type:     amqp.RabbitMQMessage_ENCYCLOPEDIA_ALMANAX_ANSWER,
 		Status:   amqp.RabbitMQMessage_SUCCESS,
 		Language: language,
 		EncyclopediaAlmanaxAnswer: &amqp.EncyclopediaAlmanaxAnswer{
 			Almanax: MapAlmanax(dodugoAlmanax, sourceService),
-			Source:  constants.GetDofusDudeSource(),
+			Source:  MapSource(source),
 		},
 	}
 }
 
+func MapAlmanaxCalendar(almanaxes []*amqp.Almanax, source constants.Source,
+	language amqp.Language) *amqp.RabbitMQMessage {
+	return &amqp.RabbitMQMessage{
+		Type:     amqp.RabbitMQMessage_ENCYCLOPEDIA_ALMANAX_CALENDAR_ANSWER,
+		Status:   amqp.RabbitMQMessage_SUCCESS,
+		Language: language,
+		EncyclopediaAlmanaxCalendarAnswer: &amqp.EncyclopediaAlmanaxCalendarAnswer{
+			Almanaxes: almanaxes,
+			Source:    MapSource(source),
+		},
+	}
+}
+
+// MapAlmanaxTribute maps the days requiring the item as tribute, page by page of size days;
+// a nil tribute stands for an item not found.
+func MapAlmanaxTribute(query string, tribute *constants.TributeAlmanaxes, size int64,
+	source constants.Source, language amqp.Language) *amqp.RabbitMQMessage {
+	answer := amqp.EncyclopediaAlmanaxTributeAnswer{
+		Query:     query,
+		Almanaxes: make([]*amqp.Almanax, 0),
+		Source:    MapSource(source),
+	}
+
+	if tribute != nil {
+		answer.ItemId = tribute.ItemID
+		answer.ItemName = tribute.ItemName
+		answer.Almanaxes = tribute.Almanaxes
+		answer.Page = tribute.Offset / size
+		answer.Pages = tribute.Total / size
+		if tribute.Total%size != 0 {
+			answer.Pages++
+		}
+		answer.Total = tribute.Total
+	}
+
+	return &amqp.RabbitMQMessage{
+		Type:                             amqp.RabbitMQMessage_ENCYCLOPEDIA_ALMANAX_TRIBUTE_ANSWER,
+		Status:                           amqp.RabbitMQMessage_SUCCESS,
+		Language:                         language,
+		EncyclopediaAlmanaxTributeAnswer: &answer,
+	}
+}
+
 func MapAlmanax(dodugoAlmanax *dodugo.Almanax, sourceService sources.Service,
 ) *amqp.Almanax {
 	if dodugoAlmanax == nil {
 		return nil
 	}
 
-	date, err := time.Parse(constants.DofusDudeAlmanaxDateFormat, *dodugoAlmanax.Date)
-	if err != nil {
-		log.Warn().
-			Str(constants.LogDate, *dodugoAlmanax.Date).
-			Msgf("Cannot cast dofusdude almanax date, continuing with time.Now...")
-		date = time.Now()
-	}
-
 	icon := *dodugoAlmanax.Tribute.Item.GetImageUrls().Icon
 	if dodugoAlmanax.Tribute.Item.GetImageUrls().Sd.IsSet() {
 		icon = *dodugoAlmanax.Tribute.Item.GetImageUrls().Sd.Get()
@@ -104,46 +141,78 @@ func MapAlmanax(dodugoAlmanax *dodugo.Almanax, sourceService sources.Service,
 			Quantity: int64(*dodugoAlmanax.Tribute.Quantity),
 		},
 		Reward: int64(dodugoAlmanax.GetRewardKamas()),
-		Date:   timestamppb.New(date.UTC()),
+		Date:   timestamppb.New(mapAlmanaxDate(dodugoAlmanax).UTC()),
 	}
 }
 
-func MapAlmanaxEffectList(dodugoAlmanaxEffects []dodugo.GetMetaAlmanaxBonuses200ResponseInner,
-) *amqp.EncyclopediaListAnswer {
-	effects := make([]*amqp.EncyclopediaListAnswer_Item, 0)
-
+func MapAlmanaxEffectListEntries(dodugoAlmanaxEffects []dodugo.GetMetaAlmanaxBonuses200ResponseInner,
+) []constants.ListEntry {
+	entries := make([]constants.ListEntry, 0, len(dodugoAlmanaxEffects))
 	for _, effect := range dodugoAlmanaxEffects {
-		effects = append(effects, &amqp.EncyclopediaListAnswer_Item{
-			Id:   fmt.Sprintf("%v", effect.GetId()),
+		entries = append(entries, constants.ListEntry{
+			ID:   fmt.Sprintf("%v", effect.GetId()),
 			Name: effect.GetName(),
+			Type: amqp.ItemType_ANY_ITEM_TYPE,
 		})
 	}
 
-	return &amqp.EncyclopediaListAnswer{
-		Items: effects,
-	}
+	return entries
 }
 
-func MapAlmanaxResource(dodugoAlmanax []dodugo.Almanax, dayDuration int64,
-	sourceService sources.Service, language amqp.Language) *amqp.RabbitMQMessage {
-	quantityPerResource := make(map[string]int64, 0)
-	for _, almanax := range dodugoAlmanax {
-		itemName := *almanax.Tribute.GetItem().Name
-		quantity, found := quantityPerResource[itemName]
+func MapTributeShoppingList(dodugoAlmanaxes []dodugo.Almanax, tributeSort constants.TributeSort,
+	sourceService sources.Service) *constants.TributeShoppingList {
+	tributes := make([]constants.Tribute, 0)
+	tributeIndexes := make(map[int32]int)
+	var reward int64
+	for i := range dodugoAlmanaxes {
+		dodugoAlmanax := &dodugoAlmanaxes[i]
+		reward += int64(dodugoAlmanax.GetRewardKamas())
+		tribute := dodugoAlmanax.GetTribute()
+		item := tribute.GetItem()
+		index, found := tributeIndexes[item.GetAnkamaId()]
 		if !found {
-			quantity = 0
+			index = len(tributes)
+			tributeIndexes[item.GetAnkamaId()] = index
+			tributes = append(tributes, constants.Tribute{
+				ItemID:   fmt.Sprintf("%v", item.GetAnkamaId()),
+				ItemName: item.GetName(),
+				ItemIcon: mapIcon(item.GetImageUrls()),
+				ItemType: sourceService.GetItemType(item.GetSubtype()),
+				Dates:    make([]time.Time, 0),
+			})
 		}
 
-		quantityPerResource[itemName] = quantity + int64(almanax.Tribute.GetQuantity())
+		tributes[index].Quantity += int64(tribute.GetQuantity())
+		tributes[index].Dates = append(tributes[index].Dates, mapAlmanaxDate(dodugoAlmanax))
 	}
 
-	tributes := make([]*amqp.EncyclopediaAlmanaxResourceAnswer_Tribute, 0)
-	for _, almanax := range dodugoAlmanax {
-		itemName := *almanax.Tribute.GetItem().Name
+	for _, tribute := range tributes {
+		slices.SortFunc(tribute.Dates, time.Time.Compare)
+	}
+	sortTributes(tributes, tributeSort)
+
+	return &constants.TributeShoppingList{
+		Tributes: tributes,
+		Reward:   reward,
+	}
+}
+
+func MapAlmanaxResource(shoppingList *constants.TributeShoppingList, dayDuration int64,
+	source constants.Source, language amqp.Language) *amqp.RabbitMQMessage {
+	tributes := make([]*amqp.EncyclopediaAlmanaxResourceAnswer_Tribute, 0, len(shoppingList.Tributes))
+	for _, tribute := range shoppingList.Tributes {
+		dates := make([]*timestamppb.Timestamp, 0, len(tribute.Dates))
+		for _, date := range tribute.Dates {
+			dates = append(dates, timestamppb.New(date.UTC()))
+		}
+
 		tributes = append(tributes, &amqp.EncyclopediaAlmanaxResourceAnswer_Tribute{
-			ItemName: itemName,
-			ItemType: sourceService.GetItemType(almanax.Tribute.Item.GetSubtype()),
-			Quantity: quantityPerResource[itemName],
+			ItemId:   tribute.ItemID,
+			ItemName: tribute.ItemName,
+			ItemIcon: tribute.ItemIcon,
+			ItemType: tribute.ItemType,
+			Quantity: tribute.Quantity,
+			Dates:    dates,
 		})
 	}
 
@@ -154,7 +223,49 @@ func MapAlmanaxResource(dodugoAlmanax []dodugo.Almanax, dayDuration int64,
 		EncyclopediaAlmanaxResourceAnswer: &amqp.EncyclopediaAlmanaxResourceAnswer{
 			Tributes: tributes,
 			Duration: dayDuration,
-			Source:   constants.GetDofusDudeSource(),
+			Reward:   shoppingList.Reward,
+			Source:   MapSource(source),
 		},
 	}
 }
+
+func MapTributeSort(tributeSort amqp.EncyclopediaAlmanaxResourceRequest_Sort) constants.TributeSort {
+	switch tributeSort {
+	case amqp.EncyclopediaAlmanaxResourceRequest_QUANTITY:
+		return constants.TributeSortQuantity
+	case amqp.EncyclopediaAlmanaxResourceRequest_DATE:
+		return constants.TributeSortDate
+	default:
+		return constants.TributeSortDate
+	}
+}
+
+// sortTributes expects tribute dates to be sorted already; ties are broken by item name.
+func sortTributes(tributes []constants.Tribute, tributeSort constants.TributeSort) {
+	slices.SortStableFunc(tributes, func(a, b constants.Tribute) int {
+		var result int
+		switch tributeSort {
+		case constants.TributeSortQuantity:
+			result = cmp.Compare(b.Quantity, a.Quantity)
+		case constants.TributeSortDate:
+			result = a.Dates[0].Compare(b.Dates[0])
+		}
+
+		if result != 0 {
+			return result
+		}
+		return cmp.Compare(a.ItemName, b.ItemName)
+	})
+}
+
+func mapAlmanaxDate(dodugoAlmanax *dodugo.Almanax) time.Time {
+	date, err := time.Parse(constants.DofusDudeAlmanaxDateFormat, dodugoAlmanax.GetDate())
+	if err != nil {
+		log.Warn().
+			Str(constants.LogDate, dodugoAlmanax.GetDate()).
+			Msgf("Cannot cast dofusdude almanax date, continuing with time.Now...")
+		return time.Now()
+	}
+
+	return date
+}
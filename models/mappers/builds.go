@@ -0,0 +1,87 @@
+package mappers
+
+import (
+	"strconv"
+
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+)
+
+func MapBuildRequest(request *amqp.EncyclopediaBuildRequest) (constants.BuildRequest, error) {
+	equipmentIDs := make([]int64, 0, len(request.GetEquipmentIds()))
+	for _, equipmentID := range request.GetEquipmentIds() {
+		itemID, err := strconv.ParseInt(equipmentID, 10, 32)
+		if err != nil {
+			return constants.BuildRequest{}, err
+		}
+
+		equipmentIDs = append(equipmentIDs, itemID)
+	}
+
+	return constants.BuildRequest{
+		Level:           request.GetLevel(),
+		Alignment:       request.GetAlignment(),
+		Characteristics: MapCharacteristics(request.GetCharacteristics()),
+		EquipmentIDs:    equipmentIDs,
+	}, nil
+}
+
+func MapBuild(build *constants.Build, source constants.Source, language amqp.Language) *amqp.RabbitMQMessage {
+	equipments := make([]*amqp.EncyclopediaBuildAnswer_Equipment, 0, len(build.Equipments))
+	for _, equipment := range build.Equipments {
+		equipments = append(equipments, &amqp.EncyclopediaBuildAnswer_Equipment{
+			Id:    equipment.ID,
+			Name:  equipment.Name,
+			Type:  equipment.Type,
+			Level: equipment.Level,
+			X:     int64(equipment.Slot.X),
+			Y:     int64(equipment.Slot.Y),
+		})
+	}
+
+	sets := make([]*amqp.SetBonus, 0, len(build.Sets))
+	for _, setBonus := range build.Sets {
+		sets = append(sets, mapSetBonus(setBonus))
+	}
+
+	buildErrors := make([]*amqp.EncyclopediaBuildAnswer_Error, 0, len(build.Errors))
+	for _, buildError := range build.Errors {
+		buildErrors = append(buildErrors, &amqp.EncyclopediaBuildAnswer_Error{
+			Type:        mapBuildErrorType(buildError.Type),
+			EquipmentId: buildError.EquipmentID,
+			Conditions:  mapConditionLeaves(buildError.Conditions),
+		})
+	}
+
+	return &amqp.RabbitMQMessage{
+		Type:     amqp.RabbitMQMessage_ENCYCLOPEDIA_BUILD_ANSWER,
+		Status:   amqp.RabbitMQMessage_SUCCESS,
+		Language: language,
+		EncyclopediaBuildAnswer: &amqp.EncyclopediaBuildAnswer{
+			Equipments:      equipments,
+			Sets:            sets,
+			Characteristics: mapEffectCharacteristics(build.Characteristics),
+			Errors:          buildErrors,
+			Source:          MapSource(source),
+		},
+	}
+}
+
+func mapBuildErrorType(errorType constants.BuildErrorType) amqp.EncyclopediaBuildAnswer_Error_Type {
+	switch errorType {
+	case constants.BuildErrorSlotFull:
+		return amqp.EncyclopediaBuildAnswer_Error_SLOT_FULL
+	case constants.BuildErrorDuplicate:
+		return amqp.EncyclopediaBuildAnswer_Error_DUPLICATE
+	case constants.BuildErrorLevelTooHigh:
+		return amqp.EncyclopediaBuildAnswer_Error_LEVEL_TOO_HIGH
+	case constants.BuildErrorConditionNotMet:
+		return amqp.EncyclopediaBuildAnswer_Error_CONDITION_NOT_MET
+	case constants.BuildErrorMissing:
+		return amqp.EncyclopediaBuildAnswer_Error_MISSING
+	case constants.BuildErrorUnknownSlot:
+		return amqp.EncyclopediaBuildAnswer_Error_UNKNOWN_SLOT
+	default:
+		return amqp.EncyclopediaBuildAnswer_Error_UNKNOWN_SLOT
+	}
+}
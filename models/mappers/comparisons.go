@@ -0,0 +1,187 @@
+package mappers
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dofusdude/dodugo"
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+	"github.com/kaellybot/kaelly-encyclopedia/services/equipments"
+	"github.com/kaellybot/kaelly-encyclopedia/utils/conditions"
+	"github.com/kaellybot/kaelly-encyclopedia/utils/effects"
+)
+
+func MapEquipmentComparison(items []*dodugo.Weapon, profile *constants.CharacterProfile, lg string,
+	equipmentService equipments.Service) *constants.EquipmentComparison {
+	comparedEquipments := make([]constants.ComparedEquipment, 0, len(items))
+	weaponLines := make([][]constants.Characteristic, 0, len(items))
+	itemEffects := make([][]constants.Characteristic, 0, len(items))
+	for _, item := range items {
+		equipmentType := mapEquipmentType(item.GetType(), equipmentService)
+		var conditionReport *constants.ConditionReport
+		if profile != nil {
+			report := conditions.EvaluateNullable(item.Conditions, *profile, lg)
+			conditionReport = &report
+		}
+
+		comparedEquipments = append(comparedEquipments, constants.ComparedEquipment{
+			ID:              fmt.Sprintf("%v", item.GetAnkamaId()),
+			Name:            item.GetName(),
+			Type:            equipmentType.EquipmentID,
+			Level:           int64(item.GetLevel()),
+			Characteristics: mapCharacteristics(item, equipmentType, equipmentService),
+			Conditions:      mapNullableConditions(item.Conditions),
+			ConditionReport: conditionReport,
+		})
+		weaponLines = append(weaponLines, effects.SumWeaponLines(item.GetEffects()))
+		itemEffects = append(itemEffects, effects.Sum(item.GetEffects()))
+	}
+
+	if len(comparedEquipments) > 0 {
+		reference := comparedEquipments[0]
+		for i := range comparedEquipments {
+			comparedEquipments[i].LevelDelta = comparedEquipments[i].Level - reference.Level
+			comparedEquipments[i].CharacteristicDeltas = mapCharacteristicDeltas(reference,
+				comparedEquipments[i])
+		}
+	}
+
+	return &constants.EquipmentComparison{
+		Equipments:    comparedEquipments,
+		WeaponEffects: mapEffectComparisons(weaponLines),
+		Effects:       mapEffectComparisons(itemEffects),
+	}
+}
+
+func MapEquipmentComparisonAnswer(comparison *constants.EquipmentComparison, source constants.Source,
+	language amqp.Language) *amqp.RabbitMQMessage {
+	equipments := make([]*amqp.EncyclopediaComparisonAnswer_Equipment, 0, len(comparison.Equipments))
+	for _, equipment := range comparison.Equipments {
+		equipments = append(equipments, &amqp.EncyclopediaComparisonAnswer_Equipment{
+			Id:                   equipment.ID,
+			Name:                 equipment.Name,
+			Type:                 equipment.Type,
+			Level:                equipment.Level,
+			LevelDelta:           equipment.LevelDelta,
+			Characteristics:      equipment.Characteristics,
+			CharacteristicDeltas: mapWeaponCharacteristicDeltas(equipment.CharacteristicDeltas),
+			Conditions:           equipment.Conditions,
+			ConditionReport:      mapConditionReport(equipment.ConditionReport),
+		})
+	}
+
+	return &amqp.RabbitMQMessage{
+		Type:     amqp.RabbitMQMessage_ENCYCLOPEDIA_COMPARISON_ANSWER,
+		Status:   amqp.RabbitMQMessage_SUCCESS,
+		Language: language,
+		EncyclopediaComparisonAnswer: &amqp.EncyclopediaComparisonAnswer{
+			Equipments:    equipments,
+			WeaponEffects: mapEffectComparisonAnswers(comparison.WeaponEffects),
+			Effects:       mapEffectComparisonAnswers(comparison.Effects),
+			Source:        MapSource(source),
+		},
+	}
+}
+
+func mapWeaponCharacteristicDeltas(deltas *constants.WeaponCharacteristicDeltas,
+) *amqp.EncyclopediaItemAnswer_Equipment_Characteristics {
+	if deltas == nil {
+		return nil
+	}
+
+	return &amqp.EncyclopediaItemAnswer_Equipment_Characteristics{
+		Cost:           deltas.Cost,
+		MinRange:       deltas.MinRange,
+		MaxRange:       deltas.MaxRange,
+		MaxCastPerTurn: deltas.MaxCastPerTurn,
+		CriticalRate:   deltas.CriticalRate,
+		CriticalBonus:  deltas.CriticalBonus,
+	}
+}
+
+func mapEffectComparisonAnswers(comparisons []constants.EffectComparison,
+) []*amqp.EncyclopediaComparisonAnswer_Effect {
+	effects := make([]*amqp.EncyclopediaComparisonAnswer_Effect, 0, len(comparisons))
+	for _, comparison := range comparisons {
+		effects = append(effects, &amqp.EncyclopediaComparisonAnswer_Effect{
+			EffectId: fmt.Sprintf("%v", comparison.EffectID),
+			Name:     comparison.Name,
+			Key:      comparison.Key,
+			Element:  mapEffectElement(comparison.Element),
+			Values:   mapEffectRanges(comparison.Values),
+			Deltas:   mapEffectRanges(comparison.Deltas),
+		})
+	}
+
+	return effects
+}
+
+func mapEffectRanges(effectRanges []constants.EffectRange) []*amqp.EffectRange {
+	result := make([]*amqp.EffectRange, 0, len(effectRanges))
+	for _, effectRange := range effectRanges {
+		result = append(result, &amqp.EffectRange{Min: effectRange.Min, Max: effectRange.Max})
+	}
+
+	return result
+}
+
+func mapCharacteristicDeltas(reference, equipment constants.ComparedEquipment,
+) *constants.WeaponCharacteristicDeltas {
+	if reference.Characteristics == nil || equipment.Characteristics == nil {
+		return nil
+	}
+
+	return &constants.WeaponCharacteristicDeltas{
+		Cost:           equipment.Characteristics.Cost - reference.Characteristics.Cost,
+		MinRange:       equipment.Characteristics.MinRange - reference.Characteristics.MinRange,
+		MaxRange:       equipment.Characteristics.MaxRange - reference.Characteristics.MaxRange,
+		MaxCastPerTurn: equipment.Characteristics.MaxCastPerTurn - reference.Characteristics.MaxCastPerTurn,
+		CriticalRate:   equipment.Characteristics.CriticalRate - reference.Characteristics.CriticalRate,
+		CriticalBonus:  equipment.Characteristics.CriticalBonus - reference.Characteristics.CriticalBonus,
+	}
+}
+
+// mapEffectComparisons matches effects by effect type, characteristics are indexed by compared equipment.
+func mapEffectComparisons(characteristics [][]constants.Characteristic) []constants.EffectComparison {
+	comparisons := make(map[int32]*constants.EffectComparison)
+	for i, itemCharacteristics := range characteristics {
+		for _, characteristic := range itemCharacteristics {
+			comparison, found := comparisons[characteristic.EffectID]
+			if !found {
+				comparison = &constants.EffectComparison{
+					EffectID: characteristic.EffectID,
+					Name:     characteristic.Name,
+					Key:      characteristic.Key,
+					Element:  characteristic.Element,
+					Values:   make([]constants.EffectRange, len(characteristics)),
+					Deltas:   make([]constants.EffectRange, len(characteristics)),
+				}
+				comparisons[characteristic.EffectID] = comparison
+			}
+
+			comparison.Values[i] = constants.EffectRange{
+				Min: characteristic.Min,
+				Max: characteristic.Max,
+			}
+		}
+	}
+
+	result := make([]constants.EffectComparison, 0, len(comparisons))
+	for _, comparison := range comparisons {
+		reference := comparison.Values[0]
+		for i, value := range comparison.Values {
+			comparison.Deltas[i] = constants.EffectRange{
+				Min: value.Min - reference.Min,
+				Max: value.Max - reference.Max,
+			}
+		}
+		result = append(result, *comparison)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].EffectID < result[j].EffectID
+	})
+
+	return result
+}
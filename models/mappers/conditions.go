@@ -0,0 +1,47 @@
+package mappers
+
+import (
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+)
+
+// MapCharacterProfile maps the requested character profile, nil if none is given.
+func MapCharacterProfile(profile *amqp.CharacterProfile) *constants.CharacterProfile {
+	if profile == nil {
+		return nil
+	}
+
+	return &constants.CharacterProfile{
+		Level:           profile.GetLevel(),
+		Alignment:       profile.GetAlignment(),
+		Characteristics: MapCharacteristics(profile.GetCharacteristics()),
+	}
+}
+
+func mapConditionReport(report *constants.ConditionReport) *amqp.ConditionReport {
+	if report == nil {
+		return nil
+	}
+
+	return &amqp.ConditionReport{
+		Equippable: report.Equippable,
+		Failures:   mapConditionLeaves(report.Failures),
+		Unchecked:  mapConditionLeaves(report.Unchecked),
+	}
+}
+
+func mapConditionLeaves(conditions []constants.Condition) []*amqp.EncyclopediaItemAnswer_Conditions_Condition {
+	leaves := make([]*amqp.EncyclopediaItemAnswer_Conditions_Condition, 0, len(conditions))
+	for _, condition := range conditions {
+		leaves = append(leaves, &amqp.EncyclopediaItemAnswer_Conditions_Condition{
+			Operator: condition.Operator,
+			Value:    condition.Value,
+			Element: &amqp.EncyclopediaItemAnswer_Conditions_Condition_Element{
+				Id:   condition.ElementID,
+				Name: condition.ElementName,
+			},
+		})
+	}
+
+	return leaves
+}
@@ -0,0 +1,96 @@
+package mappers
+
+import (
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+)
+
+// MapCraftTree maps a crafting tree; a nil tree stands for an item which cannot be found.
+func MapCraftTree(query string, tree *constants.CraftTree, source constants.Source,
+	language amqp.Language) *amqp.RabbitMQMessage {
+	answer := amqp.EncyclopediaCraftAnswer{
+		Query:               query,
+		RawResources:        make([]*amqp.EncyclopediaCraftAnswer_Resource, 0),
+		UnresolvedResources: make([]*amqp.EncyclopediaCraftAnswer_Resource, 0),
+		Source:              MapSource(source),
+	}
+
+	if tree != nil {
+		answer.Root = mapCraftNode(tree.Root)
+		answer.RawResources = mapCraftResources(tree.RawResources)
+		answer.UnresolvedResources = mapCraftResources(tree.UnresolvedResources)
+	}
+
+	return &amqp.RabbitMQMessage{
+		Type:                    amqp.RabbitMQMessage_ENCYCLOPEDIA_CRAFT_ANSWER,
+		Status:                  amqp.RabbitMQMessage_SUCCESS,
+		Language:                language,
+		EncyclopediaCraftAnswer: &answer,
+	}
+}
+
+func mapCraftNode(node *constants.CraftNode) *amqp.EncyclopediaCraftAnswer_Node {
+	children := make([]*amqp.EncyclopediaCraftAnswer_Node, 0, len(node.Children))
+	for _, child := range node.Children {
+		children = append(children, mapCraftNode(child))
+	}
+
+	return &amqp.EncyclopediaCraftAnswer_Node{
+		Id:         node.Ingredient.ID,
+		Name:       node.Ingredient.Name,
+		Type:       node.Ingredient.Type,
+		Quantity:   node.Quantity,
+		Children:   children,
+		Cyclic:     node.Cyclic,
+		Truncated:  node.Truncated,
+		Unresolved: node.Unresolved,
+	}
+}
+
+func mapCraftResources(resources []constants.RawResource) []*amqp.EncyclopediaCraftAnswer_Resource {
+	craftResources := make([]*amqp.EncyclopediaCraftAnswer_Resource, 0, len(resources))
+	for _, resource := range resources {
+		craftResources = append(craftResources, &amqp.EncyclopediaCraftAnswer_Resource{
+			Id:       resource.Ingredient.ID,
+			Name:     resource.Ingredient.Name,
+			Type:     resource.Ingredient.Type,
+			Quantity: resource.Quantity,
+		})
+	}
+
+	return craftResources
+}
+
+// MapItemUsages maps the items crafted with an ingredient; nil usages stand for an ingredient
+// which cannot be found.
+func MapItemUsages(query, itemID, itemName string, usages *constants.ItemUsages,
+	source constants.Source, language amqp.Language) *amqp.RabbitMQMessage {
+	answer := amqp.EncyclopediaUsageAnswer{
+		Query:  query,
+		Usages: make([]*amqp.EncyclopediaUsageAnswer_Usage, 0),
+		Source: MapSource(source),
+	}
+
+	if usages != nil {
+		answer.ItemId = itemID
+		answer.ItemName = itemName
+		answer.Offset = usages.Offset
+		answer.Total = usages.Total
+		for _, usage := range usages.Usages {
+			answer.Usages = append(answer.Usages, &amqp.EncyclopediaUsageAnswer_Usage{
+				Id:       usage.ID,
+				Name:     usage.Name,
+				Type:     usage.Type,
+				Level:    usage.Level,
+				Quantity: usage.Quantity,
+			})
+		}
+	}
+
+	return &amqp.RabbitMQMessage{
+		Type:                    amqp.RabbitMQMessage_ENCYCLOPEDIA_USAGE_ANSWER,
+		Status:                  amqp.RabbitMQMessage_SUCCESS,
+		Language:                language,
+		EncyclopediaUsageAnswer: &answer,
+	}
+}
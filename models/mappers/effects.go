@@ -0,0 +1,103 @@
+package mappers
+
+import (
+	"fmt"
+	"strconv"
+
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+	"github.com/kaellybot/kaelly-encyclopedia/utils/conversions"
+)
+
+// MapCharacteristics maps requested characteristics; an invalid effect ID is left to 0.
+func MapCharacteristics(characteristics []*amqp.Characteristic) []constants.Characteristic {
+	result := make([]constants.Characteristic, 0, len(characteristics))
+	for _, characteristic := range characteristics {
+		var effectID int32
+		if value, err := strconv.ParseInt(characteristic.EffectId, 10, 32); err == nil {
+			effectID, _ = conversions.Int64ToInt32(value)
+		}
+
+		result = append(result, constants.Characteristic{
+			EffectID: effectID,
+			Name:     characteristic.Name,
+			Key:      characteristic.Key,
+			Element:  mapAMQPEffectElement(characteristic.Element),
+			Min:      characteristic.Min,
+			Max:      characteristic.Max,
+		})
+	}
+
+	return result
+}
+
+func mapEffectCharacteristics(characteristics []constants.Characteristic) []*amqp.Characteristic {
+	amqpCharacteristics := make([]*amqp.Characteristic, 0, len(characteristics))
+	for _, characteristic := range characteristics {
+		amqpCharacteristics = append(amqpCharacteristics, &amqp.Characteristic{
+			EffectId: fmt.Sprintf("%v", characteristic.EffectID),
+			Name:     characteristic.Name,
+			Key:      characteristic.Key,
+			Element:  mapEffectElement(characteristic.Element),
+			Min:      characteristic.Min,
+			Max:      characteristic.Max,
+		})
+	}
+
+	return amqpCharacteristics
+}
+
+func mapEffectElement(element constants.EffectElement) amqp.EffectElement {
+	switch element {
+	case constants.EffectElementNeutral:
+		return amqp.EffectElement_NEUTRAL
+	case constants.EffectElementEarth:
+		return amqp.EffectElement_EARTH
+	case constants.EffectElementFire:
+		return amqp.EffectElement_FIRE
+	case constants.EffectElementWater:
+		return amqp.EffectElement_WATER
+	case constants.EffectElementAir:
+		return amqp.EffectElement_AIR
+	case constants.EffectElementNone:
+		return amqp.EffectElement_NONE_ELEMENT
+	default:
+		return amqp.EffectElement_NONE_ELEMENT
+	}
+}
+
+func mapEffectCategory(category constants.EffectCategory) amqp.EffectCategory {
+	switch category {
+	case constants.EffectCategoryCharacteristic:
+		return amqp.EffectCategory_CHARACTERISTIC
+	case constants.EffectCategoryDamage:
+		return amqp.EffectCategory_DAMAGE
+	case constants.EffectCategoryHeal:
+		return amqp.EffectCategory_HEAL
+	case constants.EffectCategorySteal:
+		return amqp.EffectCategory_STEAL
+	case constants.EffectCategoryOther:
+		return amqp.EffectCategory_OTHER
+	default:
+		return amqp.EffectCategory_OTHER
+	}
+}
+
+func mapAMQPEffectElement(element amqp.EffectElement) constants.EffectElement {
+	switch element {
+	case amqp.EffectElement_NEUTRAL:
+		return constants.EffectElementNeutral
+	case amqp.EffectElement_EARTH:
+		return constants.EffectElementEarth
+	case amqp.EffectElement_FIRE:
+		return constants.EffectElementFire
+	case amqp.EffectElement_WATER:
+		return constants.EffectElementWater
+	case amqp.EffectElement_AIR:
+		return constants.EffectElementAir
+	case amqp.EffectElement_NONE_ELEMENT:
+		return constants.EffectElementNone
+	default:
+		return constants.EffectElementNone
+	}
+}
@@ -9,16 +9,17 @@ import (
 	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
 	"github.com/kaellybot/kaelly-encyclopedia/models/entities"
 	"github.com/kaellybot/kaelly-encyclopedia/services/equipments"
+	"github.com/kaellybot/kaelly-encyclopedia/utils/effects"
 	"github.com/rs/zerolog/log"
 )
 
 func MapEquipment(query string, item *dodugo.Weapon, ingredientItems map[int32]*constants.Ingredient,
-	equipmentService equipments.Service) *amqp.EncyclopediaItemAnswer {
+	source constants.Source, equipmentService equipments.Service) *amqp.EncyclopediaItemAnswer {
 	if item == nil {
-		return mapNilItem(query)
+		return mapNilItem(query, source)
 	}
 
-	weaponEffects, effects := mapEffects(item.GetEffects())
+	weaponEffects, itemEffects := mapEffects(item.GetEffects())
 	recipe := mapRecipe(item.GetRecipe(), ingredientItems)
 	equipmentType := mapEquipmentType(item.GetType(), equipmentService)
 	icon := item.GetImageUrls().Icon
@@ -44,19 +45,19 @@ func MapEquipment(query string, item *dodugo.Weapon, ingredientItems map[int32]*
 			Set:             mapItemSet(item),
 			Characteristics: mapCharacteristics(item, equipmentType, equipmentService),
 			WeaponEffects:   weaponEffects,
-			Effects:         effects,
+			Effects:         itemEffects,
 			Conditions:      mapNullableConditions(item.Conditions),
 			Recipe:          recipe,
 		},
-		Source: constants.GetDofusDudeSource(),
+		Source: MapSource(source),
 	}
 }
 
-func mapNilItem(query string) *amqp.EncyclopediaItemAnswer {
+func mapNilItem(query string, source constants.Source) *amqp.EncyclopediaItemAnswer {
 	return &amqp.EncyclopediaItemAnswer{
 		Type:   amqp.ItemType_EQUIPMENT_TYPE,
 		Query:  query,
-		Source: constants.GetDofusDudeSource(),
+		Source: MapSource(source),
 	}
 }
 
@@ -76,21 +77,29 @@ func mapItemSet(item *dodugo.Weapon) *amqp.EncyclopediaItemAnswer_Equipment_SetF
 func mapEffects(allEffects []dodugo.Effect,
 ) ([]*amqp.EncyclopediaItemAnswer_Effect, []*amqp.EncyclopediaItemAnswer_Effect) {
 	weaponEffects := make([]*amqp.EncyclopediaItemAnswer_Effect, 0)
-	effects := make([]*amqp.EncyclopediaItemAnswer_Effect, 0)
+	itemEffects := make([]*amqp.EncyclopediaItemAnswer_Effect, 0)
 	for _, effect := range allEffects {
-		amqpEffect := &amqp.EncyclopediaItemAnswer_Effect{
-			Id:    fmt.Sprintf("%v", *effect.GetType().Id),
-			Label: effect.GetFormatted(),
-		}
-
-		if effect.GetType().IsActive != nil && *effect.GetType().IsActive {
-			weaponEffects = append(weaponEffects, amqpEffect)
+		structured := effects.Parse(effect)
+		if structured.IsWeaponLine {
+			weaponEffects = append(weaponEffects, mapEffect(structured))
 		} else {
-			effects = append(effects, amqpEffect)
+			itemEffects = append(itemEffects, mapEffect(structured))
 		}
 	}
 
-	return weaponEffects, effects
+	return weaponEffects, itemEffects
+}
+
+func mapEffect(effect constants.Effect) *amqp.EncyclopediaItemAnswer_Effect {
+	return &amqp.EncyclopediaItemAnswer_Effect{
+		Id:       effect.ID,
+		Label:    effect.Label,
+		Min:      effect.Min,
+		Max:      effect.Max,
+		Element:  mapEffectElement(effect.Element),
+		Category: mapEffectCategory(effect.Category),
+		Key:      effect.Key,
+	}
 }
 
 func mapCharacteristics(item *dodugo.Weapon, itemType entities.EquipmentType, service equipments.Service,
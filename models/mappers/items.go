@@ -8,21 +8,24 @@ import (
 	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
 	"github.com/kaellybot/kaelly-encyclopedia/models/entities"
 	"github.com/kaellybot/kaelly-encyclopedia/services/equipments"
+	"github.com/kaellybot/kaelly-encyclopedia/services/sources"
 )
 
-func MapItemList(dodugoItems []dodugo.GameSearch) *amqp.EncyclopediaListAnswer {
-	items := make([]*amqp.EncyclopediaListAnswer_Item, 0)
-
+func MapItemListEntries(dodugoItems []dodugo.GameSearch, sourceService sources.Service) []constants.ListEntry {
+	entries := make([]constants.ListEntry, 0, len(dodugoItems))
 	for _, item := range dodugoItems {
-		items = append(items, &amqp.EncyclopediaListAnswer_Item{
-			Id:   fmt.Sprintf("%v", item.AnkamaId),
-			Name: *item.Name,
+		itemFields := item.GetItemFields()
+		itemType := item.GetType()
+		entries = append(entries, constants.ListEntry{
+			ID:    fmt.Sprintf("%v", item.GetAnkamaId()),
+			Name:  item.GetName(),
+			Type:  sourceService.GetItemType(itemType.GetNameId()),
+			Level: int64(itemFields.GetLevel()),
+			Icon:  mapIcon(itemFields.GetImageUrls()),
 		})
 	}
 
-	return &amqp.EncyclopediaListAnswer{
-		Items: items,
-	}
+	return entries
 }
 
 func MapItem(item *amqp.EncyclopediaItemAnswer, language amqp.Language) *amqp.RabbitMQMessage {
@@ -34,11 +37,12 @@ func MapItem(item *amqp.EncyclopediaItemAnswer, language amqp.Language) *amqp.Ra
 	}
 }
 
-func MapNoItem(query string, itemType amqp.ItemType) *amqp.EncyclopediaItemAnswer {
+func MapNoItem(query string, itemType amqp.ItemType, source constants.Source,
+) *amqp.EncyclopediaItemAnswer {
 	return &amqp.EncyclopediaItemAnswer{
 		Type:   itemType,
 		Query:  query,
-		Source: constants.GetDofusDudeSource(),
+		Source: MapSource(source),
 	}
 }
 
@@ -1,6 +1,46 @@
 package mappers
 
-import amqp "github.com/kaellybot/kaelly-amqp"
+import (
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+)
+
+// MapListFilter reads the list filter from the request; the autocompletion filter
+// applies to requests without size, so that requests sent before paging keep the same answer.
+func MapListFilter(request *amqp.EncyclopediaListRequest) constants.ListFilter {
+	filter := constants.GetDefaultListFilter()
+	if request.GetSize() <= 0 {
+		return filter
+	}
+
+	filter.Offset = request.GetOffset()
+	filter.Size = request.GetSize()
+	filter.ItemType = request.GetItemType()
+	filter.MinLevel = request.GetMinLevel()
+	filter.MaxLevel = request.GetMaxLevel()
+	filter.Sort = mapListSort(request.GetSort())
+	filter.Descending = request.GetDescending()
+	return filter
+}
+
+func MapListAnswer(list *constants.List) *amqp.EncyclopediaListAnswer {
+	items := make([]*amqp.EncyclopediaListAnswer_Item, 0, len(list.Entries))
+	for _, entry := range list.Entries {
+		items = append(items, &amqp.EncyclopediaListAnswer_Item{
+			Id:    entry.ID,
+			Name:  entry.Name,
+			Level: entry.Level,
+			Type:  entry.Type,
+			Icon:  entry.Icon,
+		})
+	}
+
+	return &amqp.EncyclopediaListAnswer{
+		Items:  items,
+		Total:  list.Total,
+		Offset: list.Offset,
+	}
+}
 
 func MapList(list *amqp.EncyclopediaListAnswer, language amqp.Language) *amqp.RabbitMQMessage {
 	return &amqp.RabbitMQMessage{
@@ -10,3 +50,16 @@ func MapList(list *amqp.EncyclopediaListAnswer, language amqp.Language) *amqp.Ra
 		EncyclopediaListAnswer: list,
 	}
 }
+
+func mapListSort(sort amqp.EncyclopediaListRequest_Sort) constants.ListSort {
+	switch sort {
+	case amqp.EncyclopediaListRequest_NAME:
+		return constants.ListSortName
+	case amqp.EncyclopediaListRequest_LEVEL:
+		return constants.ListSortLevel
+	case amqp.EncyclopediaListRequest_RELEVANCE:
+		return constants.ListSortRelevance
+	default:
+		return constants.ListSortRelevance
+	}
+}
@@ -8,15 +8,14 @@ import (
 	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
 	"github.com/kaellybot/kaelly-encyclopedia/models/entities"
 	"github.com/kaellybot/kaelly-encyclopedia/services/equipments"
+	"github.com/kaellybot/kaelly-encyclopedia/utils/effects"
 )
 
-func MapMount(item *dodugo.Mount, equipmentService equipments.Service) *amqp.EncyclopediaItemAnswer {
-	effects := make([]*amqp.EncyclopediaItemAnswer_Effect, 0)
+func MapMount(item *dodugo.Mount, source constants.Source, equipmentService equipments.Service,
+) *amqp.EncyclopediaItemAnswer {
+	mountEffects := make([]*amqp.EncyclopediaItemAnswer_Effect, 0)
 	for _, effect := range item.GetEffects() {
-		effects = append(effects, &amqp.EncyclopediaItemAnswer_Effect{
-			Id:    fmt.Sprintf("%v", *effect.GetType().Id),
-			Label: effect.GetFormatted(),
-		})
+		mountEffects = append(mountEffects, mapEffect(effects.Parse(effect)))
 	}
 
 	equipmentType := mapFamilyType(item.GetFamily(), equipmentService)
@@ -38,9 +37,9 @@ func MapMount(item *dodugo.Mount, equipmentService equipments.Service) *amqp.Enc
 				EquipmentLabel: item.Family.GetName(),
 			},
 			Icon:    *icon,
-			Effects: effects,
+			Effects: mountEffects,
 		},
-		Source: constants.GetDofusDudeSource(),
+		Source: MapSource(source),
 	}
 }
 
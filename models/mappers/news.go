@@ -8,30 +8,31 @@ import (
 	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
 )
 
-func MapAlmanaxNews(almanaxes []*amqp.NewsAlmanaxMessage_I18NAlmanax) *amqp.RabbitMQMessage {
+func MapAlmanaxNews(almanaxes []*amqp.NewsAlmanaxMessage_I18NAlmanax, source constants.Source,
+) *amqp.RabbitMQMessage {
 	return &amqp.RabbitMQMessage{
 		Type:     amqp.RabbitMQMessage_NEWS_ALMANAX,
 		Language: amqp.Language_ANY,
 		Game:     amqp.Game_DOFUS_GAME,
 		NewsAlmanaxMessage: &amqp.NewsAlmanaxMessage{
 			Almanaxes: almanaxes,
-			Source:    constants.GetDofusDudeSource(),
+			Source:    MapSource(source),
 		},
 	}
 }
 
-func MapGameNews(gameVersion string) *amqp.RabbitMQMessage {
+func MapGameNews(game amqp.Game, gameVersion string) *amqp.RabbitMQMessage {
 	return &amqp.RabbitMQMessage{
 		Type:     amqp.RabbitMQMessage_NEWS_GAME,
 		Language: amqp.Language_ANY,
-		Game:     amqp.Game_DOFUS_GAME,
+		Game:     game,
 		NewsGameMessage: &amqp.NewsGameMessage{
 			Version: gameVersion,
 		},
 	}
 }
 
-func MapSetNews(sets []dodugo.ListEquipmentSet) *amqp.RabbitMQMessage {
+func MapSetNews(game amqp.Game, sets []dodugo.ListEquipmentSet) *amqp.RabbitMQMessage {
 	setIDs := make([]string, 0)
 	for _, set := range sets {
 		setIDs = append(setIDs, fmt.Sprintf("%v", set.GetAnkamaId()))
@@ -40,7 +41,7 @@ func MapSetNews(sets []dodugo.ListEquipmentSet) *amqp.RabbitMQMessage {
 	return &amqp.RabbitMQMessage{
 		Type:     amqp.RabbitMQMessage_NEWS_SET,
 		Language: amqp.Language_ANY,
-		Game:     amqp.Game_DOFUS_GAME,
+		Game:     game,
 		NewsSetMessage: &amqp.NewsSetMessage{
 			SetIds: setIDs,
 		},
@@ -0,0 +1,48 @@
+package mappers
+
+import (
+	"fmt"
+
+	"github.com/dofusdude/dodugo"
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+)
+
+// MapResource maps consumables, quest items and resources;
+// they share the equipment answer since they have the same shape, without characteristics nor set.
+func MapResource(query string, itemType amqp.ItemType, item *dodugo.Resource,
+	ingredientItems map[int32]*constants.Ingredient, source constants.Source) *amqp.EncyclopediaItemAnswer {
+	if item == nil {
+		return MapNoItem(query, itemType, source)
+	}
+
+	// Active effects are the ones applied when using a consumable, not weapon ones.
+	activeEffects, effects := mapEffects(item.GetEffects())
+	resourceType := item.GetType()
+	icon := item.GetImageUrls().Icon
+	if item.GetImageUrls().Hq.IsSet() {
+		icon = item.GetImageUrls().Hq.Get()
+	}
+
+	return &amqp.EncyclopediaItemAnswer{
+		Type:  itemType,
+		Query: query,
+		Equipment: &amqp.EncyclopediaItemAnswer_Equipment{
+			Id:          fmt.Sprintf("%v", item.GetAnkamaId()),
+			Name:        item.GetName(),
+			Description: item.GetDescription(),
+			Type: &amqp.EncyclopediaItemAnswer_Equipment_Type{
+				ItemType:       itemType,
+				EquipmentType:  amqp.EquipmentType_NONE,
+				EquipmentLabel: resourceType.GetName(),
+			},
+			Icon:       *icon,
+			Level:      int64(item.GetLevel()),
+			Pods:       int64(item.GetPods()),
+			Effects:    append(activeEffects, effects...),
+			Conditions: mapNullableConditions(item.Conditions),
+			Recipe:     mapRecipe(item.GetRecipe(), ingredientItems),
+		},
+		Source: MapSource(source),
+	}
+}
@@ -0,0 +1,107 @@
+package mappers
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/dofusdude/dodugo"
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+	"github.com/kaellybot/kaelly-encyclopedia/services/equipments"
+	"github.com/kaellybot/kaelly-encyclopedia/utils/conversions"
+)
+
+func MapEquipmentFilter(request *amqp.EncyclopediaEquipmentSearchRequest) (constants.EquipmentFilter, error) {
+	var effectID int32
+	if request.GetEffectId() != "" {
+		value, err := strconv.ParseInt(request.GetEffectId(), 10, 32)
+		if err != nil {
+			return constants.EquipmentFilter{}, err
+		}
+
+		effectID, err = conversions.Int64ToInt32(value)
+		if err != nil {
+			return constants.EquipmentFilter{}, err
+		}
+	}
+
+	return constants.EquipmentFilter{
+		EquipmentType: request.GetEquipmentType(),
+		MinLevel:      request.GetMinLevel(),
+		MaxLevel:      request.GetMaxLevel(),
+		EffectID:      effectID,
+		MinValue:      request.MinValue,
+		MaxValue:      request.MaxValue,
+		Sort:          mapEquipmentSort(request.GetSort()),
+		Descending:    request.GetDescending(),
+		Offset:        request.GetOffset(),
+		Size:          request.GetSize(),
+	}, nil
+}
+
+func MapEquipmentSearchResult(item dodugo.ListItem, effect *constants.Characteristic,
+	equipmentService equipments.Service) constants.EquipmentSearchResult {
+	return constants.EquipmentSearchResult{
+		ID:     fmt.Sprintf("%v", item.GetAnkamaId()),
+		Name:   item.GetName(),
+		Type:   mapEquipmentType(item.GetType(), equipmentService).EquipmentID,
+		Level:  int64(item.GetLevel()),
+		Icon:   mapIcon(item.GetImageUrls()),
+		Effect: effect,
+	}
+}
+
+func MapEquipmentSearch(search *constants.EquipmentSearch,
+	source constants.Source, language amqp.Language) *amqp.RabbitMQMessage {
+	answer := amqp.EncyclopediaEquipmentSearchAnswer{
+		Equipments: make([]*amqp.EncyclopediaEquipmentSearchAnswer_Equipment, 0),
+		Source:     MapSource(source),
+	}
+
+	if search != nil {
+		answer.Offset = search.Offset
+		answer.Total = search.Total
+		for _, result := range search.Results {
+			equipment := &amqp.EncyclopediaEquipmentSearchAnswer_Equipment{
+				Id:    result.ID,
+				Name:  result.Name,
+				Type:  result.Type,
+				Level: result.Level,
+				Icon:  result.Icon,
+			}
+			if result.Effect != nil {
+				equipment.Effect = mapEffectCharacteristics([]constants.Characteristic{*result.Effect})[0]
+			}
+
+			answer.Equipments = append(answer.Equipments, equipment)
+		}
+	}
+
+	return &amqp.RabbitMQMessage{
+		Type:                              amqp.RabbitMQMessage_ENCYCLOPEDIA_EQUIPMENT_SEARCH_ANSWER,
+		Status:                            amqp.RabbitMQMessage_SUCCESS,
+		Language:                          language,
+		EncyclopediaEquipmentSearchAnswer: &answer,
+	}
+}
+
+func mapEquipmentSort(sort amqp.EncyclopediaEquipmentSearchRequest_Sort) constants.EquipmentSort {
+	switch sort {
+	case amqp.EncyclopediaEquipmentSearchRequest_NAME:
+		return constants.EquipmentSortName
+	case amqp.EncyclopediaEquipmentSearchRequest_EFFECT_VALUE:
+		return constants.EquipmentSortEffectValue
+	case amqp.EncyclopediaEquipmentSearchRequest_LEVEL:
+		return constants.EquipmentSortLevel
+	default:
+		return constants.EquipmentSortLevel
+	}
+}
+
+func mapIcon(imageURLs dodugo.Images) string {
+	if sd := imageURLs.GetSd(); sd != "" {
+		return sd
+	}
+
+	return imageURLs.GetIcon()
+}
@@ -9,32 +9,28 @@ import (
 	amqp "github.com/kaellybot/kaelly-amqp"
 	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
 	"github.com/kaellybot/kaelly-encyclopedia/services/equipments"
+	"github.com/kaellybot/kaelly-encyclopedia/utils/effects"
 	"github.com/rs/zerolog/log"
 )
 
-func MapSetList(dodugoSets []dodugo.ListEquipmentSet) *amqp.EncyclopediaListAnswer {
-	sets := make([]*amqp.EncyclopediaListAnswer_Item, 0)
-
-	for _, set := range dodugoSets {
-		sets = append(sets, &amqp.EncyclopediaListAnswer_Item{
-			Id:   fmt.Sprintf("%v", set.GetAnkamaId()),
-			Name: set.GetName(),
-		})
-	}
-
-	return &amqp.EncyclopediaListAnswer{
-		Items: sets,
+func MapSetListEntry(set dodugo.ListEquipmentSet, icon string) constants.ListEntry {
+	return constants.ListEntry{
+		ID:    fmt.Sprintf("%v", set.GetAnkamaId()),
+		Name:  set.GetName(),
+		Type:  amqp.ItemType_SET_TYPE,
+		Level: int64(set.GetLevel()),
+		Icon:  icon,
 	}
 }
 
 func MapSet(query string, set *dodugo.EquipmentSet, items map[int32]*dodugo.Weapon,
-	icon string, equipmentService equipments.Service,
+	icon string, source constants.Source, equipmentService equipments.Service,
 ) *amqp.EncyclopediaItemAnswer {
 	if set == nil {
 		return &amqp.EncyclopediaItemAnswer{
 			Type:   amqp.ItemType_SET_TYPE,
 			Query:  query,
-			Source: constants.GetDofusDudeSource(),
+			Source: MapSource(source),
 		}
 	}
 
@@ -75,17 +71,14 @@ func MapSet(query string, set *dodugo.EquipmentSet, items map[int32]*dodugo.Weap
 			continue
 		}
 
-		effects := make([]*amqp.EncyclopediaItemAnswer_Effect, 0)
+		bonusEffects := make([]*amqp.EncyclopediaItemAnswer_Effect, 0)
 		for _, effect := range bonus {
-			effects = append(effects, &amqp.EncyclopediaItemAnswer_Effect{
-				Id:    fmt.Sprintf("%v", *effect.GetType().Id),
-				Label: effect.GetFormatted(),
-			})
+			bonusEffects = append(bonusEffects, mapEffect(effects.Parse(effect)))
 		}
 
 		bonuses = append(bonuses, &amqp.EncyclopediaItemAnswer_Set_Bonus{
 			ItemNumber: itemNumber,
-			Effects:    effects,
+			Effects:    bonusEffects,
 		})
 	}
 
@@ -105,6 +98,44 @@ func MapSet(query string, set *dodugo.EquipmentSet, items map[int32]*dodugo.Weap
 			Equipments: equipments,
 			Bonuses:    bonuses,
 		},
-		Source: constants.GetDofusDudeSource(),
+		Source: MapSource(source),
+	}
+}
+
+// MapSetBonus maps a partially equipped set; a nil set bonus stands for a set which cannot be found.
+func MapSetBonus(query string, setBonus *constants.SetBonus, source constants.Source,
+	language amqp.Language) *amqp.RabbitMQMessage {
+	answer := amqp.EncyclopediaSetBonusAnswer{
+		Query:  query,
+		Source: MapSource(source),
+	}
+
+	if setBonus != nil {
+		answer.SetBonus = mapSetBonus(*setBonus)
+	}
+
+	return &amqp.RabbitMQMessage{
+		Type:                       amqp.RabbitMQMessage_ENCYCLOPEDIA_SET_BONUS_ANSWER,
+		Status:                     amqp.RabbitMQMessage_SUCCESS,
+		Language:                   language,
+		EncyclopediaSetBonusAnswer: &answer,
+	}
+}
+
+func mapSetBonus(setBonus constants.SetBonus) *amqp.SetBonus {
+	equippedIDs := make([]string, 0, len(setBonus.EquippedIDs))
+	for _, equippedID := range setBonus.EquippedIDs {
+		equippedIDs = append(equippedIDs, fmt.Sprintf("%v", equippedID))
+	}
+
+	return &amqp.SetBonus{
+		SetId:           fmt.Sprintf("%v", setBonus.SetID),
+		SetName:         setBonus.SetName,
+		EquippedIds:     equippedIDs,
+		ItemNumber:      setBonus.ItemNumber,
+		Bonus:           mapEffectCharacteristics(setBonus.Bonus),
+		NextItemNumber:  setBonus.NextItemNumber,
+		NextGains:       mapEffectCharacteristics(setBonus.NextGains),
+		Characteristics: mapEffectCharacteristics(setBonus.Characteristics),
 	}
 }
@@ -0,0 +1,15 @@
+package mappers
+
+import (
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+)
+
+func MapSource(source constants.Source) *amqp.Source {
+	return &amqp.Source{
+		Name:  source.Name,
+		Icon:  source.Icon,
+		Url:   source.URL,
+		Stale: source.Stale,
+	}
+}
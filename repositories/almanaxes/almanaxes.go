@@ -9,6 +9,10 @@ func New(db databases.MySQLConnection) *Impl {
 	return &Impl{db: db}
 }
 
+func (repo *Impl) Migrate() error {
+	return repo.db.GetDB().AutoMigrate(&entities.Almanax{})
+}
+
 func (repo *Impl) GetAlmanaxes() ([]entities.Almanax, error) {
 	var almanaxes []entities.Almanax
 	response := repo.db.GetDB().
@@ -6,6 +6,8 @@ import (
 )
 
 type Repository interface {
+	// Migrate adds the missing almanax columns, such as the tribute ID, to an existing schema.
+	Migrate() error
 	GetAlmanaxes() ([]entities.Almanax, error)
 	Save(almanax entities.Almanax) error
 }
@@ -23,9 +23,16 @@ func New(scheduler gocron.Scheduler, frenchLocation *time.Location, repository r
 	service := Impl{
 		frenchLocation: frenchLocation,
 		almanaxes:      make(map[string][]entities.Almanax),
+		tributes:       make(map[int32][]entities.Almanax),
 		sourceService:  sourceService,
 		newsService:    newsService,
 		repository:     repository,
+		prewarmDays:    viper.GetInt(constants.PrewarmAlmanaxDays),
+	}
+
+	errMigrate := repository.Migrate()
+	if errMigrate != nil {
+		return nil, errMigrate
 	}
 
 	errDB := service.loadAlmanaxEffectsFromDB()
@@ -35,6 +42,18 @@ func New(scheduler gocron.Scheduler, frenchLocation *time.Location, repository r
 
 	service.sourceService.ListenGameEvent(service.reconcileDofusDudeIDs)
 
+	// Days stored before tribute IDs existed are reconciled at startup rather than at the next game update.
+	if service.hasMissingTributeIDs() {
+		_, errJob := scheduler.NewJob(
+			gocron.OneTimeJob(gocron.OneTimeJobStartImmediately()),
+			gocron.NewTask(func() { service.reconcileDofusDudeIDs(constants.DefaultGame, "") }),
+			gocron.WithName("Reconcile almanax tribute IDs"),
+		)
+		if errJob != nil {
+			return nil, errJob
+		}
+	}
+
 	_, errJob := scheduler.NewJob(
 		gocron.CronJob(viper.GetString(constants.AlmanaxCronTab), true),
 		gocron.NewTask(func() { service.dispatchDailyAlmanax() }),
@@ -44,6 +63,15 @@ func New(scheduler gocron.Scheduler, frenchLocation *time.Location, repository r
 		return nil, errJob
 	}
 
+	_, errJob = scheduler.NewJob(
+		gocron.CronJob(viper.GetString(constants.PrewarmCronTab), true),
+		gocron.NewTask(func() { service.prewarmCache() }),
+		gocron.WithName("Prewarm cache"),
+	)
+	if errJob != nil {
+		return nil, errJob
+	}
+
 	return &service, nil
 }
 
@@ -52,14 +80,24 @@ func (service *Impl) GetLocation() *time.Location {
 }
 
 func (service *Impl) GetDatesByAlmanaxEffect(dofusDudeEffectID string) []time.Time {
+	service.almanaxMutex.RLock()
+	almanaxes := service.almanaxes[dofusDudeEffectID]
+	service.almanaxMutex.RUnlock()
+	return getNextDates(almanaxes)
+}
+
+func (service *Impl) GetDatesByAlmanaxTribute(dofusDudeItemID int32) []time.Time {
+	service.almanaxMutex.RLock()
+	almanaxes := service.tributes[dofusDudeItemID]
+	service.almanaxMutex.RUnlock()
+	return getNextDates(almanaxes)
+}
+
+// getNextDates returns the next occurrence of every almanax day, from today.
+func getNextDates(almanaxes []entities.Almanax) []time.Time {
 	now := time.Now().UTC()
 	dates := make([]time.Time, 0)
-	entities, found := service.almanaxes[dofusDudeEffectID]
-	if !found {
-		return dates
-	}
-
-	for _, entity := range entities {
+	for _, entity := range almanaxes {
 		year := now.Year()
 		if time.Month(entity.Month) < now.Month() ||
 			time.Month(entity.Month) == now.Month() && entity.Day < now.Day() {
@@ -94,16 +132,42 @@ func (service *Impl) loadAlmanaxEffectsFromDB() error {
 		Int(constants.LogEntityCount, len(almanaxes)).
 		Msgf("Almanaxes loaded")
 
+	almanaxesByEffect := make(map[string][]entities.Almanax)
+	almanaxesByTribute := make(map[int32][]entities.Almanax)
 	for _, almanax := range almanaxes {
-		effects := service.almanaxes[almanax.DofusDudeEffectID]
-		service.almanaxes[almanax.DofusDudeEffectID] = append(effects, almanax)
+		effects := almanaxesByEffect[almanax.DofusDudeEffectID]
+		almanaxesByEffect[almanax.DofusDudeEffectID] = append(effects, almanax)
+		if almanax.DofusDudeTributeID != 0 {
+			tributes := almanaxesByTribute[almanax.DofusDudeTributeID]
+			almanaxesByTribute[almanax.DofusDudeTributeID] = append(tributes, almanax)
+		}
 	}
 
+	service.almanaxMutex.Lock()
+	service.almanaxes = almanaxesByEffect
+	service.tributes = almanaxesByTribute
+	service.almanaxMutex.Unlock()
+
 	return nil
 }
 
+func (service *Impl) hasMissingTributeIDs() bool {
+	service.almanaxMutex.RLock()
+	defer service.almanaxMutex.RUnlock()
+	for _, almanaxes := range service.almanaxes {
+		for _, almanax := range almanaxes {
+			if almanax.DofusDudeTributeID == 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 func (service *Impl) dispatchDailyAlmanax() {
 	log.Info().Msgf("Dispatching daily almanax...")
+	ctx := sources.WithSourceTracking(context.Background())
 	almanaxes := make([]*amqp.NewsAlmanaxMessage_I18NAlmanax, 0)
 	for _, value := range amqp.Language_value {
 		lg := amqp.Language(value)
@@ -120,7 +184,7 @@ func (service *Impl) dispatchDailyAlmanax() {
 				" continuing without this almanax", lg)
 			continue
 		}
-		almanax, err := service.sourceService.GetAlmanaxByDate(context.Background(), day, dofusDudeLg)
+		almanax, err := service.sourceService.GetAlmanaxByDate(ctx, day, dofusDudeLg)
 		if err != nil {
 			log.Warn().Err(err).
 				Msgf("Cannot retrieve almanax from DofusDude (lg=%v), continuing without it", lg)
@@ -133,10 +197,41 @@ func (service *Impl) dispatchDailyAlmanax() {
 		})
 	}
 
-	service.newsService.PublishAlmanaxNews(almanaxes)
+	service.newsService.PublishAlmanaxNews(almanaxes, sources.GetServingSource(ctx))
 }
 
-func (service *Impl) reconcileDofusDudeIDs(_ string) {
+// prewarmCache puts the next almanax days in every language into cache,
+// as well as the items requested the most since the last prewarming.
+func (service *Impl) prewarmCache() {
+	log.Info().Msgf("Prewarming almanax cache...")
+	ctx := context.Background()
+	languages := make(map[string]struct{})
+	for _, language := range constants.GetLanguages() {
+		languages[language] = struct{}{}
+	}
+
+	today := time.Now().In(service.frenchLocation)
+	for i := 1; i <= service.prewarmDays; i++ {
+		day := today.AddDate(0, 0, i)
+		for language := range languages {
+			_, err := service.sourceService.GetAlmanaxByDate(ctx, day, language)
+			if err != nil {
+				log.Warn().Err(err).
+					Str(constants.LogDate, day.Format(constants.DofusDudeAlmanaxDateFormat)).
+					Msgf("Cannot prewarm almanax (lg=%v), continuing without it", language)
+			}
+		}
+	}
+
+	service.sourceService.PrewarmPopularItems(ctx)
+}
+
+func (service *Impl) reconcileDofusDudeIDs(game amqp.Game, _ string) {
+	// Almanax only exists in Dofus.
+	if game != constants.DefaultGame {
+		return
+	}
+
 	log.Info().Msgf("Reconciling almanax DofusDude IDs...")
 	ctx := context.Background()
 	year := time.Now().Year()
@@ -177,7 +272,9 @@ func (service *Impl) reconcileDofusDudeIDs(_ string) {
 		Msg("Almanax dates reconciliated!")
 
 	errLoad := service.loadAlmanaxEffectsFromDB()
-	log.Warn().Err(errLoad).Msg("Could not reload almanax from DB, please restart to take them in account")
+	if errLoad != nil {
+		log.Warn().Err(errLoad).Msg("Could not reload almanax from DB, please restart to take them in account")
+	}
 }
 
 func (service *Impl) reconcileDofusDudeID(ctx context.Context, entity entities.Almanax, year int,
@@ -193,8 +290,12 @@ func (service *Impl) reconcileDofusDudeID(ctx context.Context, entity entities.A
 		return false, errNotFound
 	}
 
-	if dodugoAlmanax.Bonus.Type.GetId() != entity.DofusDudeEffectID {
+	tribute := dodugoAlmanax.GetTribute()
+	tributeItem := tribute.GetItem()
+	if dodugoAlmanax.Bonus.Type.GetId() != entity.DofusDudeEffectID ||
+		tributeItem.GetAnkamaId() != entity.DofusDudeTributeID {
 		entity.DofusDudeEffectID = dodugoAlmanax.Bonus.Type.GetId()
+		entity.DofusDudeTributeID = tributeItem.GetAnkamaId()
 		return true, service.repository.Save(entity)
 	}
 
@@ -2,6 +2,7 @@ package almanaxes
 
 import (
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/kaellybot/kaelly-encyclopedia/models/entities"
@@ -16,13 +17,17 @@ var (
 
 type Service interface {
 	GetDatesByAlmanaxEffect(dofusDudeEffectID string) []time.Time
+	GetDatesByAlmanaxTribute(dofusDudeItemID int32) []time.Time
 	GetLocation() *time.Location
 }
 
 type Impl struct {
 	frenchLocation *time.Location
 	almanaxes      map[string][]entities.Almanax
+	tributes       map[int32][]entities.Almanax
+	almanaxMutex   sync.RWMutex
 	sourceService  sources.Service
 	newsService    news.Service
 	repository     repository.Repository
+	prewarmDays    int
 }
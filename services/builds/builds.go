@@ -0,0 +1,152 @@
+package builds
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"sort"
+
+	"github.com/dofusdude/dodugo"
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+	"github.com/kaellybot/kaelly-encyclopedia/services/equipments"
+	"github.com/kaellybot/kaelly-encyclopedia/services/sets"
+	"github.com/kaellybot/kaelly-encyclopedia/services/sources"
+	"github.com/kaellybot/kaelly-encyclopedia/utils/effects"
+	"github.com/rs/zerolog/log"
+)
+
+func New(sourceService sources.Service, equipmentService equipments.Service,
+	setService sets.Service) *Impl {
+	return &Impl{
+		sourceService:    sourceService,
+		equipmentService: equipmentService,
+		setService:       setService,
+	}
+}
+
+func (service *Impl) Simulate(ctx context.Context, request constants.BuildRequest, correlationID,
+	lg string) (*constants.Build, error) {
+	build := constants.Build{
+		Equipments: make([]constants.BuildEquipment, 0),
+		Sets:       make([]constants.SetBonus, 0),
+		Errors:     make([]constants.BuildError, 0),
+	}
+
+	points := constants.GetBuildPoints()
+	occupied := make(map[image.Point]int64)
+	items := make([]*dodugo.Weapon, 0)
+	for _, itemID := range request.EquipmentIDs {
+		item, err := service.sourceService.GetEquipmentByID(ctx, itemID, lg)
+		if err != nil || item == nil {
+			log.Error().Err(err).
+				Str(constants.LogCorrelationID, correlationID).
+				Str(constants.LogAnkamaID, fmt.Sprintf("%v", itemID)).
+				Msgf("Error while retrieving equipment, continuing without it")
+			build.Errors = append(build.Errors, constants.BuildError{
+				Type:        constants.BuildErrorMissing,
+				EquipmentID: fmt.Sprintf("%v", itemID),
+			})
+			continue
+		}
+
+		equipmentType := amqp.EquipmentType_NONE
+		itemType := item.GetType()
+		dofusDudeType, found := service.equipmentService.GetTypeByDofusDude(itemType.GetId())
+		if found {
+			equipmentType = dofusDudeType.EquipmentID
+		}
+
+		slot, errorType, ok := getSlot(points[equipmentType], itemID, occupied)
+		if !ok {
+			build.Errors = append(build.Errors, constants.BuildError{
+				Type:        errorType,
+				EquipmentID: fmt.Sprintf("%v", itemID),
+			})
+			continue
+		}
+
+		occupied[slot] = itemID
+		items = append(items, item)
+		build.Equipments = append(build.Equipments, constants.BuildEquipment{
+			ID:    fmt.Sprintf("%v", itemID),
+			Name:  item.GetName(),
+			Type:  equipmentType,
+			Level: int64(item.GetLevel()),
+			Slot:  slot,
+		})
+	}
+
+	build.Sets = service.getSetBonuses(ctx, items, correlationID, lg)
+	characteristicLists := [][]constants.Characteristic{request.Characteristics}
+	for _, item := range items {
+		characteristicLists = append(characteristicLists, effects.Sum(item.GetEffects()))
+	}
+	for _, setBonus := range build.Sets {
+		characteristicLists = append(characteristicLists, setBonus.Bonus)
+	}
+	build.Characteristics = effects.Merge(characteristicLists...)
+	build.Errors = append(build.Errors, checkRequirements(items, constants.CharacterProfile{
+		Level:           request.Level,
+		Alignment:       request.Alignment,
+		Characteristics: build.Characteristics,
+	}, lg)...)
+
+	return &build, nil
+}
+
+// getSlot returns the first free point among the item type ones.
+// If the item cannot be equipped, the reason is returned instead.
+func getSlot(points []image.Point, itemID int64, occupied map[image.Point]int64,
+) (image.Point, constants.BuildErrorType, bool) {
+	if len(points) == 0 {
+		return image.Point{}, constants.BuildErrorUnknownSlot, false
+	}
+
+	for _, point := range points {
+		if equippedID, found := occupied[point]; found && equippedID == itemID {
+			return image.Point{}, constants.BuildErrorDuplicate, false
+		}
+	}
+
+	for _, point := range points {
+		if _, found := occupied[point]; !found {
+			return point, 0, true
+		}
+	}
+
+	return image.Point{}, constants.BuildErrorSlotFull, false
+}
+
+// getSetBonuses computes the bonus of every set which has at least one equipped item, sorted by set ID.
+func (service *Impl) getSetBonuses(ctx context.Context, items []*dodugo.Weapon, correlationID,
+	lg string) []constants.SetBonus {
+	equippedIDs := make(map[int64][]int64)
+	for _, item := range items {
+		if item.HasParentSet() {
+			parentSet := item.GetParentSet()
+			setID := int64(parentSet.GetId())
+			equippedIDs[setID] = append(equippedIDs[setID], int64(item.GetAnkamaId()))
+		}
+	}
+
+	setBonuses := make([]constants.SetBonus, 0)
+	for setID, itemIDs := range equippedIDs {
+		setBonus, err := service.setService.GetSetBonus(ctx, setID, itemIDs, correlationID, lg)
+		if err != nil {
+			log.Error().Err(err).
+				Str(constants.LogCorrelationID, correlationID).
+				Str(constants.LogAnkamaID, fmt.Sprintf("%v", setID)).
+				Msgf("Error while computing set bonus, continuing without it")
+			continue
+		}
+
+		setBonuses = append(setBonuses, *setBonus)
+	}
+
+	sort.Slice(setBonuses, func(i, j int) bool {
+		return setBonuses[i].SetID < setBonuses[j].SetID
+	})
+
+	return setBonuses
+}
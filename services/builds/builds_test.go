@@ -0,0 +1,94 @@
+package builds
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dofusdude/dodugo"
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+	"github.com/kaellybot/kaelly-encyclopedia/models/entities"
+	"github.com/kaellybot/kaelly-encyclopedia/services/equipments"
+	"github.com/kaellybot/kaelly-encyclopedia/services/sources"
+)
+
+const (
+	testRingType = 9
+	testHatType  = 16
+)
+
+type fakeSourceService struct {
+	sources.Service
+	items map[int64]*dodugo.Weapon
+}
+
+func (service *fakeSourceService) GetEquipmentByID(_ context.Context, itemID int64, _ string,
+) (*dodugo.Weapon, error) {
+	item, found := service.items[itemID]
+	if !found {
+		return nil, sources.ErrNotFound
+	}
+
+	return item, nil
+}
+
+type fakeEquipmentService struct {
+	equipments.Service
+}
+
+func (service *fakeEquipmentService) GetTypeByDofusDude(id int32) (entities.EquipmentType, bool) {
+	equipmentTypes := map[int32]amqp.EquipmentType{
+		testRingType: amqp.EquipmentType_RING,
+		testHatType:  amqp.EquipmentType_HAT,
+	}
+
+	equipmentType, found := equipmentTypes[id]
+	return entities.EquipmentType{EquipmentID: equipmentType}, found
+}
+
+func newTestWeapon(itemID, itemType, level int32) *dodugo.Weapon {
+	return &dodugo.Weapon{
+		AnkamaId: dodugo.PtrInt32(itemID),
+		Name:     dodugo.PtrString("item"),
+		Type:     &dodugo.TranslatedId{Id: dodugo.PtrInt32(itemType)},
+		Level:    dodugo.PtrInt32(level),
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	service := New(&fakeSourceService{
+		items: map[int64]*dodugo.Weapon{
+			1: newTestWeapon(1, testRingType, 50),
+			2: newTestWeapon(2, testRingType, 50),
+			3: newTestWeapon(3, testRingType, 50),
+			5: newTestWeapon(5, testHatType, 200),
+		},
+	}, &fakeEquipmentService{}, nil)
+
+	build, err := service.Simulate(context.Background(), constants.BuildRequest{
+		Level:        100,
+		EquipmentIDs: []int64{1, 1, 2, 3, 4, 5},
+	}, "", "fr")
+	if err != nil {
+		t.Fatalf("expected a build, got %v", err)
+	}
+
+	if len(build.Equipments) != 3 {
+		t.Errorf("expected 3 equipped items, got %+v", build.Equipments)
+	}
+
+	expected := []constants.BuildError{
+		{Type: constants.BuildErrorDuplicate, EquipmentID: "1"},
+		{Type: constants.BuildErrorSlotFull, EquipmentID: "3"},
+		{Type: constants.BuildErrorMissing, EquipmentID: "4"},
+		{Type: constants.BuildErrorLevelTooHigh, EquipmentID: "5"},
+	}
+	if len(build.Errors) != len(expected) {
+		t.Fatalf("expected %+v, got %+v", expected, build.Errors)
+	}
+	for i, buildError := range build.Errors {
+		if buildError.Type != expected[i].Type || buildError.EquipmentID != expected[i].EquipmentID {
+			t.Errorf("expected %+v at position %v, got %+v", expected[i], i, buildError)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package builds
+
+import (
+	"fmt"
+
+	"github.com/dofusdude/dodugo"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+	"github.com/kaellybot/kaelly-encyclopedia/utils/conditions"
+)
+
+func checkRequirements(items []*dodugo.Weapon, profile constants.CharacterProfile,
+	lg string) []constants.BuildError {
+	buildErrors := make([]constants.BuildError, 0)
+	for _, item := range items {
+		itemID := fmt.Sprintf("%v", item.GetAnkamaId())
+		if profile.Level > 0 && int64(item.GetLevel()) > profile.Level {
+			buildErrors = append(buildErrors, constants.BuildError{
+				Type:        constants.BuildErrorLevelTooHigh,
+				EquipmentID: itemID,
+			})
+		}
+
+		report := conditions.EvaluateNullable(item.Conditions, profile, lg)
+		if !report.Equippable {
+			buildErrors = append(buildErrors, constants.BuildError{
+				Type:        constants.BuildErrorConditionNotMet,
+				EquipmentID: itemID,
+				Conditions:  report.Failures,
+			})
+		}
+	}
+
+	return buildErrors
+}
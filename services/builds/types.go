@@ -0,0 +1,23 @@
+package builds
+
+import (
+	"context"
+
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+	"github.com/kaellybot/kaelly-encyclopedia/services/equipments"
+	"github.com/kaellybot/kaelly-encyclopedia/services/sets"
+	"github.com/kaellybot/kaelly-encyclopedia/services/sources"
+)
+
+type Service interface {
+	// Simulate equips the loadout slot by slot and totals its characteristics, set bonuses included.
+	// Slot rules, unmet conditions and equipments which cannot be retrieved are reported as build errors.
+	Simulate(ctx context.Context, request constants.BuildRequest, correlationID,
+		lg string) (*constants.Build, error)
+}
+
+type Impl struct {
+	sourceService    sources.Service
+	equipmentService equipments.Service
+	setService       sets.Service
+}
@@ -0,0 +1,240 @@
+package crafts
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/dofusdude/dodugo"
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+	"github.com/kaellybot/kaelly-encyclopedia/services/sources"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+)
+
+func New(sourceService sources.Service) *Impl {
+	service := Impl{
+		sourceService: sourceService,
+		maxDepth:      viper.GetInt(constants.CraftMaxDepth),
+		usages:        make(map[amqp.Game]map[string]map[int64][]constants.ItemUsage),
+	}
+
+	//nolint:exhaustive // Ingredient types possibility is exhaustive here.
+	service.getItemFuncs = map[amqp.ItemType]getItemFunc{
+		amqp.ItemType_CONSUMABLE_TYPE: func(ctx context.Context, id int64, lg string) (*craftItem, error) {
+			return newCraftItem(service.sourceService.GetConsumableByID(ctx, id, lg))
+		},
+		amqp.ItemType_EQUIPMENT_TYPE: func(ctx context.Context, id int64, lg string) (*craftItem, error) {
+			return newCraftItem(service.sourceService.GetEquipmentByID(ctx, id, lg))
+		},
+		amqp.ItemType_QUEST_ITEM_TYPE: func(ctx context.Context, id int64, lg string) (*craftItem, error) {
+			return newCraftItem(service.sourceService.GetQuestItemByID(ctx, id, lg))
+		},
+		amqp.ItemType_RESOURCE_TYPE: func(ctx context.Context, id int64, lg string) (*craftItem, error) {
+			return newCraftItem(service.sourceService.GetResourceByID(ctx, id, lg))
+		},
+	}
+
+	service.sourceService.ListenCatalog(service.indexUsages)
+
+	return &service
+}
+
+func (service *Impl) GetIngredients(ctx context.Context, recipe []dodugo.Recipe,
+	correlationID, lg string) map[int32]*constants.Ingredient {
+	ingredients := make(map[int32]*constants.Ingredient)
+	for _, ingredient := range recipe {
+		itemID := ingredient.GetItemAnkamaId()
+		itemType := service.sourceService.GetItemType(ingredient.GetItemSubtype())
+		item, errItem := service.getItem(ctx, itemType, int64(itemID), lg)
+		if errItem != nil {
+			log.Error().Err(errItem).
+				Str(constants.LogCorrelationID, correlationID).
+				Str(constants.LogAnkamaID, fmt.Sprintf("%v", itemID)).
+				Msgf("Error while retrieving item with DofusDude, continuing without it")
+		} else {
+			ingredients[itemID] = &constants.Ingredient{
+				ID:   fmt.Sprintf("%v", itemID),
+				Name: item.name,
+				Type: itemType,
+			}
+		}
+	}
+
+	return ingredients
+}
+
+func (service *Impl) GetCraftTree(ctx context.Context, itemType amqp.ItemType, itemID, quantity int64,
+	correlationID, lg string) (*constants.CraftTree, error) {
+	builder := treeBuilder{
+		service:       service,
+		correlationID: correlationID,
+		lg:            lg,
+		items:         make(map[itemKey]*craftItem),
+	}
+
+	rootKey := itemKey{itemType: itemType, itemID: itemID}
+	if _, err := builder.getItem(ctx, rootKey); err != nil {
+		return nil, err
+	}
+
+	root := builder.build(ctx, rootKey, quantity, 0, make(map[itemKey]struct{}))
+	rawResources, unresolvedResources := flatten(root)
+	return &constants.CraftTree{
+		Root:                root,
+		RawResources:        rawResources,
+		UnresolvedResources: unresolvedResources,
+	}, nil
+}
+
+func (service *Impl) getItem(ctx context.Context, itemType amqp.ItemType, itemID int64,
+	lg string) (*craftItem, error) {
+	getItemFunc, found := service.getItemFuncs[itemType]
+	if !found {
+		return nil, sources.ErrNotFound
+	}
+
+	return getItemFunc(ctx, itemID, lg)
+}
+
+// build resolves the node of an item and its sub-recipes; an ingredient which cannot be retrieved
+// is kept as an unresolved node rather than failing the whole tree.
+func (builder *treeBuilder) build(ctx context.Context, key itemKey, quantity int64, depth int,
+	ancestors map[itemKey]struct{}) *constants.CraftNode {
+	node := constants.CraftNode{
+		Ingredient: constants.Ingredient{
+			ID:   fmt.Sprintf("%v", key.itemID),
+			Type: key.itemType,
+		},
+		Quantity: quantity,
+		Children: make([]*constants.CraftNode, 0),
+	}
+
+	item, err := builder.getItem(ctx, key)
+	if err != nil {
+		log.Error().Err(err).
+			Str(constants.LogCorrelationID, builder.correlationID).
+			Str(constants.LogAnkamaID, node.Ingredient.ID).
+			Msgf("Error while retrieving crafting tree ingredient, continuing without its recipe")
+		node.Unresolved = true
+		return &node
+	}
+
+	node.Ingredient.Name = item.name
+	if len(item.recipe) == 0 {
+		return &node
+	}
+
+	if _, found := ancestors[key]; found {
+		node.Cyclic = true
+		return &node
+	}
+
+	if depth >= builder.service.maxDepth {
+		node.Truncated = true
+		return &node
+	}
+
+	ancestors[key] = struct{}{}
+	defer delete(ancestors, key)
+	for _, ingredient := range item.recipe {
+		ingredientKey := itemKey{
+			itemType: builder.service.sourceService.GetItemType(ingredient.GetItemSubtype()),
+			itemID:   int64(ingredient.GetItemAnkamaId()),
+		}
+
+		node.Children = append(node.Children, builder.build(ctx, ingredientKey,
+			quantity*int64(ingredient.GetQuantity()), depth+1, ancestors))
+	}
+
+	return &node
+}
+
+// getItem retrieves each item once per tree, since the same ingredient is often used in several sub-recipes.
+func (builder *treeBuilder) getItem(ctx context.Context, key itemKey) (*craftItem, error) {
+	if item, found := builder.items[key]; found {
+		return item, nil
+	}
+
+	item, err := builder.service.getItem(ctx, key.itemType, key.itemID, builder.lg)
+	if err != nil {
+		return nil, err
+	}
+
+	builder.items[key] = item
+	return item, nil
+}
+
+// flatten totals the leaves of a crafting tree, the most needed resources first.
+// Leaves whose recipe has not been resolved are totaled apart from raw resources.
+func flatten(root *constants.CraftNode) ([]constants.RawResource, []constants.RawResource) {
+	rawResources := newResourceTotals()
+	unresolvedResources := newResourceTotals()
+	nodes := []*constants.CraftNode{root}
+	for len(nodes) > 0 {
+		node := nodes[len(nodes)-1]
+		nodes = nodes[:len(nodes)-1]
+		switch {
+		case len(node.Children) > 0:
+			nodes = append(nodes, node.Children...)
+		case node.Cyclic || node.Truncated || node.Unresolved:
+			unresolvedResources.add(node)
+		default:
+			rawResources.add(node)
+		}
+	}
+
+	return rawResources.sorted(), unresolvedResources.sorted()
+}
+
+func newResourceTotals() *resourceTotals {
+	return &resourceTotals{
+		resources: make([]constants.RawResource, 0),
+		indexes:   make(map[constants.Ingredient]int),
+	}
+}
+
+func (totals *resourceTotals) add(node *constants.CraftNode) {
+	if index, found := totals.indexes[node.Ingredient]; found {
+		totals.resources[index].Quantity += node.Quantity
+		return
+	}
+
+	totals.indexes[node.Ingredient] = len(totals.resources)
+	totals.resources = append(totals.resources, constants.RawResource{
+		Ingredient: node.Ingredient,
+		Quantity:   node.Quantity,
+	})
+}
+
+func (totals *resourceTotals) sorted() []constants.RawResource {
+	resources := totals.resources
+	sort.SliceStable(resources, func(i, j int) bool {
+		if resources[i].Quantity != resources[j].Quantity {
+			return resources[i].Quantity > resources[j].Quantity
+		}
+		return resources[i].Ingredient.Name < resources[j].Ingredient.Name
+	})
+
+	return resources
+}
+
+func newCraftItem[T any, PT interface {
+	*T
+	GetName() string
+	GetRecipe() []dodugo.Recipe
+}](item PT, err error) (*craftItem, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	if item == nil {
+		return nil, sources.ErrNotFound
+	}
+
+	return &craftItem{
+		name:   item.GetName(),
+		recipe: item.GetRecipe(),
+	}, nil
+}
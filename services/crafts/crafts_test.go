@@ -0,0 +1,60 @@
+package crafts
+
+import (
+	"testing"
+
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+)
+
+func newTestNode(name string, quantity int64, children ...*constants.CraftNode) *constants.CraftNode {
+	return &constants.CraftNode{
+		Ingredient: constants.Ingredient{ID: name, Name: name},
+		Quantity:   quantity,
+		Children:   children,
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	cyclic := newTestNode("Planche Agglomérée", 2)
+	cyclic.Cyclic = true
+	truncated := newTestNode("Ferrite", 1)
+	truncated.Truncated = true
+	unresolved := newTestNode("404", 3)
+	unresolved.Unresolved = true
+
+	root := newTestNode("Anneau", 1,
+		newTestNode("Laine de Bouftou", 4),
+		newTestNode("Alliage", 2,
+			newTestNode("Laine de Bouftou", 6),
+			newTestNode("Fer", 10),
+			cyclic,
+		),
+		truncated,
+		unresolved,
+	)
+
+	rawResources, unresolvedResources := flatten(root)
+
+	expectedRawResources := []constants.RawResource{
+		{Ingredient: constants.Ingredient{ID: "Fer", Name: "Fer"}, Quantity: 10},
+		{Ingredient: constants.Ingredient{ID: "Laine de Bouftou", Name: "Laine de Bouftou"}, Quantity: 10},
+	}
+	if len(rawResources) != len(expectedRawResources) {
+		t.Fatalf("expected %v raw resources, got %v", expectedRawResources, rawResources)
+	}
+	for i, rawResource := range rawResources {
+		if rawResource != expectedRawResources[i] {
+			t.Errorf("expected %v at position %v, got %v", expectedRawResources[i], i, rawResource)
+		}
+	}
+
+	expectedUnresolved := []string{"404", "Planche Agglomérée", "Ferrite"}
+	if len(unresolvedResources) != len(expectedUnresolved) {
+		t.Fatalf("expected %v unresolved resources, got %v", expectedUnresolved, unresolvedResources)
+	}
+	for i, unresolvedResource := range unresolvedResources {
+		if unresolvedResource.Ingredient.Name != expectedUnresolved[i] {
+			t.Errorf("expected %v at position %v, got %v", expectedUnresolved[i], i, unresolvedResource.Ingredient.Name)
+		}
+	}
+}
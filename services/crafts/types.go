@@ -0,0 +1,65 @@
+package crafts
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/dofusdude/dodugo"
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+	"github.com/kaellybot/kaelly-encyclopedia/services/sources"
+)
+
+var (
+	ErrIndexNotBuilt = errors.New("usage index is not built yet")
+)
+
+type Service interface {
+	// GetIngredients resolves the first level of a recipe; ingredients which cannot be retrieved are left out.
+	GetIngredients(ctx context.Context, recipe []dodugo.Recipe, correlationID,
+		lg string) map[int32]*constants.Ingredient
+	// GetCraftTree resolves the sub-recipes of every craftable ingredient needed to craft
+	// the given quantity of an item, and totals the raw resources at the leaves of the tree.
+	// Ingredients which cannot be retrieved are kept as unresolved nodes; the item itself must be found.
+	GetCraftTree(ctx context.Context, itemType amqp.ItemType, itemID, quantity int64,
+		correlationID, lg string) (*constants.CraftTree, error)
+	// GetItemUsages returns the items whose recipe uses the ingredient, sorted by level;
+	// maxLevel is ignored if not positive, every usage is returned if size is not positive.
+	GetItemUsages(ctx context.Context, ingredientID, minLevel, maxLevel, offset, size int64,
+		lg string) (*constants.ItemUsages, error)
+}
+
+type Impl struct {
+	sourceService sources.Service
+	maxDepth      int
+	getItemFuncs  map[amqp.ItemType]getItemFunc
+	usages        map[amqp.Game]map[string]map[int64][]constants.ItemUsage
+	usageMutex    sync.RWMutex
+}
+
+type getItemFunc func(ctx context.Context, ID int64, lg string) (*craftItem, error)
+
+type craftItem struct {
+	name   string
+	recipe []dodugo.Recipe
+}
+
+type itemKey struct {
+	itemType amqp.ItemType
+	itemID   int64
+}
+
+// treeBuilder holds the state of one crafting tree resolution.
+type treeBuilder struct {
+	service       *Impl
+	correlationID string
+	lg            string
+	items         map[itemKey]*craftItem
+}
+
+// resourceTotals sums the quantities of tree leaves, one per ingredient.
+type resourceTotals struct {
+	resources []constants.RawResource
+	indexes   map[constants.Ingredient]int
+}
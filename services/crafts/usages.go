@@ -0,0 +1,86 @@
+package crafts
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/dofusdude/dodugo"
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+	"github.com/kaellybot/kaelly-encyclopedia/services/sources"
+	"github.com/rs/zerolog/log"
+)
+
+func (service *Impl) GetItemUsages(ctx context.Context, ingredientID, minLevel, maxLevel int64,
+	offset, size int64, lg string) (*constants.ItemUsages, error) {
+	service.usageMutex.RLock()
+	languageUsages, found := service.usages[sources.GetGame(ctx)][lg]
+	service.usageMutex.RUnlock()
+	if !found {
+		return nil, ErrIndexNotBuilt
+	}
+
+	usages := make([]constants.ItemUsage, 0)
+	for _, usage := range languageUsages[ingredientID] {
+		if usage.Level >= minLevel && (maxLevel <= 0 || usage.Level <= maxLevel) {
+			usages = append(usages, usage)
+		}
+	}
+
+	offset = min(max(offset, 0), int64(len(usages)))
+	end := int64(len(usages))
+	if size > 0 {
+		end = min(offset+size, end)
+	}
+
+	return &constants.ItemUsages{
+		Usages: usages[offset:end],
+		Offset: offset,
+		Total:  int64(len(usages)),
+	}, nil
+}
+
+// indexUsages indexes the recipes of a game language catalog, by ingredient.
+// It is called with the catalogs retrieved to build autocomplete indexes.
+func (service *Impl) indexUsages(game amqp.Game, language string, catalog *sources.Catalog) {
+	usages := make(map[int64][]constants.ItemUsage)
+	indexItemUsages(usages, amqp.ItemType_CONSUMABLE_TYPE, catalog.Consumables)
+	indexItemUsages(usages, amqp.ItemType_EQUIPMENT_TYPE, catalog.Equipments)
+	indexItemUsages(usages, amqp.ItemType_RESOURCE_TYPE, catalog.Resources)
+	sortItemUsages(usages)
+
+	service.usageMutex.Lock()
+	if _, found := service.usages[game]; !found {
+		service.usages[game] = make(map[string]map[int64][]constants.ItemUsage)
+	}
+	service.usages[game][language] = usages
+	service.usageMutex.Unlock()
+	log.Info().Msgf("%v item usage index built for '%v'", game, language)
+}
+
+func indexItemUsages(usages map[int64][]constants.ItemUsage, itemType amqp.ItemType, items []dodugo.ListItem) {
+	for _, item := range items {
+		for _, ingredient := range item.GetRecipe() {
+			ingredientID := int64(ingredient.GetItemAnkamaId())
+			usages[ingredientID] = append(usages[ingredientID], constants.ItemUsage{
+				ID:       fmt.Sprintf("%v", item.GetAnkamaId()),
+				Name:     item.GetName(),
+				Type:     itemType,
+				Level:    int64(item.GetLevel()),
+				Quantity: int64(ingredient.GetQuantity()),
+			})
+		}
+	}
+}
+
+func sortItemUsages(usages map[int64][]constants.ItemUsage) {
+	for _, itemUsages := range usages {
+		sort.SliceStable(itemUsages, func(i, j int) bool {
+			if itemUsages[i].Level != itemUsages[j].Level {
+				return itemUsages[i].Level < itemUsages[j].Level
+			}
+			return itemUsages[i].Name < itemUsages[j].Name
+		})
+	}
+}
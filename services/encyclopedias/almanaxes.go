@@ -3,12 +3,16 @@ package encyclopedias
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/dofusdude/dodugo"
 	amqp "github.com/kaellybot/kaelly-amqp"
 	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
 	"github.com/kaellybot/kaelly-encyclopedia/models/mappers"
 	"github.com/kaellybot/kaelly-encyclopedia/services/sources"
+	"github.com/kaellybot/kaelly-encyclopedia/utils/conversions"
+	"github.com/kaellybot/kaelly-encyclopedia/utils/rankings"
 	"github.com/rs/zerolog/log"
 )
 
@@ -23,7 +27,8 @@ func (service *Impl) almanaxRequest(ctx amqp.Context, message *amqp.RabbitMQMess
 
 	// Only case where we need to retrieve the precise local date to have the right day (and so the almanax).
 	frenchDate := request.Date.AsTime().In(service.almanaxService.GetLocation())
-	almanax, err := service.sourceService.GetAlmanaxByDate(ctx, frenchDate, lg)
+	trackedCtx := sources.WithSourceTracking(sources.WithGame(ctx, message.Game))
+	almanax, err := service.sourceService.GetAlmanaxByDate(trackedCtx, frenchDate, lg)
 	if err != nil {
 		log.Error().Str(constants.LogCorrelationID, ctx.CorrelationID).
 			Str(constants.LogDate, request.Date.String()).
@@ -33,7 +38,8 @@ func (service *Impl) almanaxRequest(ctx amqp.Context, message *amqp.RabbitMQMess
 		return
 	}
 
-	response := mappers.MapAlmanaxAnswer(almanax, service.sourceService, message.Language)
+	response := mappers.MapAlmanaxAnswer(almanax, sources.GetServingSource(trackedCtx),
+		service.sourceService, message.Language)
 	service.replyWithSuceededAnswer(ctx, response)
 }
 
@@ -49,11 +55,12 @@ func (service *Impl) almanaxEffectRequest(ctx amqp.Context, message *amqp.Rabbit
 	log.Info().Str(constants.LogCorrelationID, ctx.CorrelationID).
 		Msgf("Get almanax effect encyclopedia request received")
 
-	effect, errEffect := service.getEffectFromRequest(ctx, request, lg)
+	trackedCtx := sources.WithSourceTracking(sources.WithGame(ctx, message.Game))
+	effect, errEffect := service.getEffectFromRequest(trackedCtx, request, lg)
 	if errEffect != nil {
 		if errors.Is(errEffect, sources.ErrNotFound) {
 			response := mappers.MapAlmanaxEffects(request, "", nil, 0,
-				service.sourceService, message.Language)
+				sources.GetServingSource(trackedCtx), service.sourceService, message.Language)
 			service.replyWithSuceededAnswer(ctx, response)
 			return
 		}
@@ -69,26 +76,17 @@ func (service *Impl) almanaxEffectRequest(ctx amqp.Context, message *amqp.Rabbit
 		return
 	}
 
-	offset := request.GetOffset()
-	adjustedSize := offset + request.GetSize()
-	dodugoAlmanaxes := make([]*dodugo.Almanax, 0)
 	almanaxDates := service.almanaxService.GetDatesByAlmanaxEffect(*effect.Id)
-	for i := offset; i < adjustedSize && i < int64(len(almanaxDates)); i++ {
-		dodugoAlmanax, err := service.sourceService.GetAlmanaxByDate(ctx, almanaxDates[i], lg)
-		if err != nil {
-			log.Error().Str(constants.LogCorrelationID, ctx.CorrelationID).
-				Str(constants.LogDate, almanaxDates[i].String()).
-				Msgf("Error while handling encyclopedia almanax date, returning failed request")
-			service.replyWithFailedAnswer(ctx, amqp.RabbitMQMessage_ENCYCLOPEDIA_ALMANAX_EFFECT_ANSWER,
-				message.Language)
-			return
-		}
-
-		dodugoAlmanaxes = append(dodugoAlmanaxes, dodugoAlmanax)
+	dodugoAlmanaxes, err := service.getAlmanaxesByDates(trackedCtx, almanaxDates,
+		request.GetOffset(), request.GetSize(), ctx.CorrelationID, lg)
+	if err != nil {
+		service.replyWithFailedAnswer(ctx, amqp.RabbitMQMessage_ENCYCLOPEDIA_ALMANAX_EFFECT_ANSWER,
+			message.Language)
+		return
 	}
 
 	response := mappers.MapAlmanaxEffects(request, effect.GetName(), dodugoAlmanaxes,
-		int64(len(almanaxDates)), service.sourceService, message.Language)
+		int64(len(almanaxDates)), sources.GetServingSource(trackedCtx), service.sourceService, message.Language)
 	service.replyWithSuceededAnswer(ctx, response)
 }
 
@@ -104,9 +102,11 @@ func (service *Impl) almanaxResourceRequest(ctx amqp.Context, message *amqp.Rabb
 	log.Info().Str(constants.LogCorrelationID, ctx.CorrelationID).
 		Msgf("Get almanax resources encyclopedia request received")
 
-	almanax, err := service.sourceService.GetAlmanaxByRange(ctx, request.Duration, lg)
+	trackedCtx := sources.WithSourceTracking(sources.WithGame(ctx, message.Game))
+	shoppingList, err := service.getTributeShoppingListFromRequest(trackedCtx, request, lg)
 	if err != nil {
-		log.Error().Str(constants.LogCorrelationID, ctx.CorrelationID).
+		log.Error().Err(err).
+			Str(constants.LogCorrelationID, ctx.CorrelationID).
 			Int64(constants.LogDuration, request.Duration).
 			Msgf("Error while handling encyclopedia almanax resources, returning failed request")
 		service.replyWithFailedAnswer(ctx, amqp.RabbitMQMessage_ENCYCLOPEDIA_ALMANAX_RESOURCE_ANSWER,
@@ -114,10 +114,196 @@ func (service *Impl) almanaxResourceRequest(ctx amqp.Context, message *amqp.Rabb
 		return
 	}
 
-	response := mappers.MapAlmanaxResource(almanax, request.Duration, service.sourceService, message.Language)
+	response := mappers.MapAlmanaxResource(shoppingList, request.Duration, sources.GetServingSource(trackedCtx),
+		message.Language)
 	service.replyWithSuceededAnswer(ctx, response)
 }
 
+func (service *Impl) almanaxCalendarRequest(ctx amqp.Context, message *amqp.RabbitMQMessage) {
+	request := message.EncyclopediaAlmanaxCalendarRequest
+	lg := mappers.MapLanguage(message.Language)
+	if !isValidAlmanaxCalendarRequest(request) {
+		service.replyWithFailedAnswer(ctx, amqp.RabbitMQMessage_ENCYCLOPEDIA_ALMANAX_CALENDAR_ANSWER,
+			message.Language)
+		return
+	}
+
+	log.Info().Str(constants.LogCorrelationID, ctx.CorrelationID).
+		Str(constants.LogDate, request.GetStart().String()).
+		Msgf("Get almanax calendar encyclopedia request received")
+
+	// Days are the local ones, as for a single almanax.
+	start := request.GetStart().AsTime().In(service.almanaxService.GetLocation())
+	end := request.GetEnd().AsTime().In(service.almanaxService.GetLocation())
+	trackedCtx := sources.WithSourceTracking(sources.WithGame(ctx, message.Game))
+	almanaxes, err := service.GetAlmanaxCalendar(trackedCtx, start, end, lg)
+	if err != nil {
+		log.Error().Err(err).
+			Str(constants.LogCorrelationID, ctx.CorrelationID).
+			Str(constants.LogDate, request.GetStart().String()).
+			Msgf("Error while handling encyclopedia almanax calendar, returning failed request")
+		service.replyWithFailedAnswer(ctx, amqp.RabbitMQMessage_ENCYCLOPEDIA_ALMANAX_CALENDAR_ANSWER,
+			message.Language)
+		return
+	}
+
+	response := mappers.MapAlmanaxCalendar(almanaxes, sources.GetServingSource(trackedCtx), message.Language)
+	service.replyWithSuceededAnswer(ctx, response)
+}
+
+func (service *Impl) almanaxTributeRequest(ctx amqp.Context, message *amqp.RabbitMQMessage) {
+	request := message.EncyclopediaAlmanaxTributeRequest
+	lg := mappers.MapLanguage(message.Language)
+	if !isValidAlmanaxTributeRequest(request) {
+		service.replyWithFailedAnswer(ctx, amqp.RabbitMQMessage_ENCYCLOPEDIA_ALMANAX_TRIBUTE_ANSWER,
+			message.Language)
+		return
+	}
+
+	query := request.GetItem().GetQuery()
+	log.Info().Str(constants.LogCorrelationID, ctx.CorrelationID).
+		Str(constants.LogQueryID, query).
+		Str(constants.LogQueryType, request.GetItem().GetType().String()).
+		Msgf("Get almanax tribute encyclopedia request received")
+
+	trackedCtx := sources.WithSourceTracking(sources.WithGame(ctx, message.Game))
+	tribute, err := service.GetAlmanaxesByTribute(trackedCtx, request.GetItem(), request.GetOffset(),
+		request.GetSize(), ctx.CorrelationID, lg)
+	if err != nil {
+		if errors.Is(err, sources.ErrNotFound) {
+			response := mappers.MapAlmanaxTribute(query, nil, request.GetSize(),
+				sources.GetServingSource(trackedCtx), message.Language)
+			service.replyWithSuceededAnswer(ctx, response)
+			return
+		}
+
+		log.Error().Err(err).
+			Str(constants.LogCorrelationID, ctx.CorrelationID).
+			Str(constants.LogQueryID, query).
+			Msgf("Error while handling encyclopedia almanax tribute, returning failed request")
+		service.replyWithFailedAnswer(ctx, amqp.RabbitMQMessage_ENCYCLOPEDIA_ALMANAX_TRIBUTE_ANSWER,
+			message.Language)
+		return
+	}
+
+	response := mappers.MapAlmanaxTribute(query, tribute, request.GetSize(),
+		sources.GetServingSource(trackedCtx), message.Language)
+	service.replyWithSuceededAnswer(ctx, response)
+}
+
+func (service *Impl) GetAlmanaxCalendar(ctx context.Context, start, end time.Time,
+	lg string) ([]*amqp.Almanax, error) {
+	dodugoAlmanaxes, err := service.sourceService.GetAlmanaxBetweenDates(ctx, start, end, lg)
+	if err != nil {
+		return nil, err
+	}
+
+	almanaxes := make([]*amqp.Almanax, 0, len(dodugoAlmanaxes))
+	for i := range dodugoAlmanaxes {
+		almanaxes = append(almanaxes, mappers.MapAlmanax(&dodugoAlmanaxes[i], service.sourceService))
+	}
+
+	return almanaxes, nil
+}
+
+func (service *Impl) GetTributeShoppingList(ctx context.Context, start, end time.Time,
+	tributeSort constants.TributeSort, lg string) (*constants.TributeShoppingList, error) {
+	dodugoAlmanaxes, err := service.sourceService.GetAlmanaxBetweenDates(ctx, start, end, lg)
+	if err != nil {
+		return nil, err
+	}
+
+	return mappers.MapTributeShoppingList(dodugoAlmanaxes, tributeSort, service.sourceService), nil
+}
+
+// getTributeShoppingListFromRequest aggregates the tributes between the requested dates if any,
+// from today for the requested duration otherwise.
+func (service *Impl) getTributeShoppingListFromRequest(ctx context.Context,
+	request *amqp.EncyclopediaAlmanaxResourceRequest, lg string) (*constants.TributeShoppingList, error) {
+	tributeSort := mappers.MapTributeSort(request.GetSort())
+	if request.GetStart() != nil {
+		start := request.GetStart().AsTime().In(service.almanaxService.GetLocation())
+		end := request.GetEnd().AsTime().In(service.almanaxService.GetLocation())
+		return service.GetTributeShoppingList(ctx, start, end, tributeSort, lg)
+	}
+
+	dodugoAlmanaxes, err := service.sourceService.GetAlmanaxByRange(ctx, request.GetDuration(), lg)
+	if err != nil {
+		return nil, err
+	}
+
+	return mappers.MapTributeShoppingList(dodugoAlmanaxes, tributeSort, service.sourceService), nil
+}
+
+func (service *Impl) GetAlmanaxesByTribute(ctx context.Context, request *amqp.EncyclopediaItemRequest,
+	offset, size int64, correlationID, lg string) (*constants.TributeAlmanaxes, error) {
+	itemID, itemName, err := service.getTributeFromRequest(ctx, request, lg)
+	if err != nil {
+		return nil, err
+	}
+
+	almanaxDates := service.almanaxService.GetDatesByAlmanaxTribute(itemID)
+	dodugoAlmanaxes, err := service.getAlmanaxesByDates(ctx, almanaxDates, offset, size, correlationID, lg)
+	if err != nil {
+		return nil, err
+	}
+
+	almanaxes := make([]*amqp.Almanax, 0, len(dodugoAlmanaxes))
+	for _, dodugoAlmanax := range dodugoAlmanaxes {
+		almanax := mappers.MapAlmanax(dodugoAlmanax, service.sourceService)
+		if almanax == nil {
+			continue
+		}
+
+		if itemName == "" {
+			itemName = almanax.Tribute.Item.Name
+		}
+		almanaxes = append(almanaxes, almanax)
+	}
+
+	return &constants.TributeAlmanaxes{
+		ItemID:    fmt.Sprintf("%v", itemID),
+		ItemName:  itemName,
+		Almanaxes: almanaxes,
+		Offset:    offset,
+		Total:     int64(len(almanaxDates)),
+	}, nil
+}
+
+// getTributeFromRequest returns the item ID and name; the name is unknown when the item is requested by ID.
+func (service *Impl) getTributeFromRequest(ctx context.Context, request *amqp.EncyclopediaItemRequest,
+	lg string) (int32, string, error) {
+	_, itemID, itemName, err := service.getItemFromRequest(ctx, request.Query, request.GetIsID(),
+		request.GetType(), lg)
+	if err != nil {
+		return 0, "", err
+	}
+
+	int32ItemID, err := conversions.Int64ToInt32(itemID)
+	return int32ItemID, itemName, err
+}
+
+// getAlmanaxesByDates retrieves the almanax of the dates from offset, at most size of them.
+func (service *Impl) getAlmanaxesByDates(ctx context.Context, dates []time.Time, offset, size int64,
+	correlationID, lg string) ([]*dodugo.Almanax, error) {
+	offset = max(offset, 0)
+	adjustedSize := offset + size
+	dodugoAlmanaxes := make([]*dodugo.Almanax, 0)
+	for i := offset; i < adjustedSize && i < int64(len(dates)); i++ {
+		dodugoAlmanax, err := service.sourceService.GetAlmanaxByDate(ctx, dates[i], lg)
+		if err != nil {
+			log.Error().Str(constants.LogCorrelationID, correlationID).
+				Str(constants.LogDate, dates[i].String()).
+				Msgf("Error while handling encyclopedia almanax date, returning failed request")
+			return nil, err
+		}
+
+		dodugoAlmanaxes = append(dodugoAlmanaxes, dodugoAlmanax)
+	}
+
+	return dodugoAlmanaxes, nil
+}
+
 func (service *Impl) getEffectFromRequest(ctx context.Context, request *amqp.EncyclopediaAlmanaxEffectRequest,
 	lg string) (*dodugo.GetMetaAlmanaxBonuses200ResponseInner, error) {
 	switch request.Type {
@@ -131,8 +317,9 @@ func (service *Impl) getEffectFromRequest(ctx context.Context, request *amqp.Enc
 			return nil, sources.ErrNotFound
 		}
 
-		// We trust the omnisearch by taking the first one in the list
-		return &values[0], nil
+		best := rankings.BestMatch(request.Query, values,
+			func(effect dodugo.GetMetaAlmanaxBonuses200ResponseInner) string { return effect.GetName() })
+		return &values[best], nil
 	case amqp.EncyclopediaAlmanaxEffectRequest_DATE:
 		dodugoAlmanax, errGet := service.sourceService.
 			GetAlmanaxByDate(ctx, request.GetDate().AsTime(), lg)
@@ -153,6 +340,16 @@ func isValidAlmanaxEffectRequest(request *amqp.EncyclopediaAlmanaxEffectRequest)
 	return request != nil
 }
 
+// isValidAlmanaxResourceRequest requires both dates or none of them.
 func isValidAlmanaxResourceRequest(request *amqp.EncyclopediaAlmanaxResourceRequest) bool {
-	return request != nil
+	return request != nil && (request.GetStart() == nil) == (request.GetEnd() == nil)
+}
+
+func isValidAlmanaxCalendarRequest(request *amqp.EncyclopediaAlmanaxCalendarRequest) bool {
+	return request != nil && request.GetStart() != nil && request.GetEnd() != nil
+}
+
+// isValidAlmanaxTributeRequest requires a positive size and no negative offset, days being paginated.
+func isValidAlmanaxTributeRequest(request *amqp.EncyclopediaAlmanaxTributeRequest) bool {
+	return request != nil && request.GetItem() != nil && request.GetSize() > 0 && request.GetOffset() >= 0
 }
@@ -0,0 +1,328 @@
+package encyclopedias
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dofusdude/dodugo"
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+	"github.com/kaellybot/kaelly-encyclopedia/services/almanaxes"
+	"github.com/kaellybot/kaelly-encyclopedia/services/sources"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type fakeAlmanaxService struct {
+	almanaxes.Service
+	location     *time.Location
+	tributeDates map[int32][]time.Time
+}
+
+func (service *fakeAlmanaxService) GetLocation() *time.Location {
+	return service.location
+}
+
+func (service *fakeAlmanaxService) GetDatesByAlmanaxTribute(dofusDudeItemID int32) []time.Time {
+	return service.tributeDates[dofusDudeItemID]
+}
+
+// newTestAlmanax requires a single tribute 0 on even days, ten tributes 1 on odd days.
+func newTestAlmanax(date time.Time) *dodugo.Almanax {
+	itemID, quantity := int32(date.Day()%2), int32(1)
+	if itemID == 1 {
+		quantity = 10
+	}
+
+	return &dodugo.Almanax{
+		Bonus: &dodugo.AlmanaxBonus{Description: dodugo.PtrString("bonus")},
+		Tribute: &dodugo.AlmanaxTribute{
+			Item: &dodugo.AlmanaxTributeItem{
+				AnkamaId:  dodugo.PtrInt32(itemID),
+				Name:      dodugo.PtrString(fmt.Sprintf("tribute %v", itemID)),
+				ImageUrls: &dodugo.Images{Icon: dodugo.PtrString("icon")},
+			},
+			Quantity: dodugo.PtrInt32(quantity),
+		},
+		Date:        dodugo.PtrString(date.Format(constants.DofusDudeAlmanaxDateFormat)),
+		RewardKamas: *dodugo.NewNullableInt32(dodugo.PtrInt32(100)),
+	}
+}
+
+type fakeAlmanaxSourceService struct {
+	sources.Service
+	start time.Time
+}
+
+func (service *fakeAlmanaxSourceService) GetAlmanaxBetweenDates(_ context.Context, start, end time.Time,
+	_ string) ([]dodugo.Almanax, error) {
+	service.start = start
+	if end.Before(start) {
+		return nil, sources.ErrInvalidRange
+	}
+
+	dodugoAlmanaxes := make([]dodugo.Almanax, 0)
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		dodugoAlmanaxes = append(dodugoAlmanaxes, *newTestAlmanax(date))
+	}
+
+	return dodugoAlmanaxes, nil
+}
+
+func (service *fakeAlmanaxSourceService) GetAlmanaxByDate(_ context.Context, date time.Time,
+	_ string) (*dodugo.Almanax, error) {
+	return newTestAlmanax(date), nil
+}
+
+func (service *fakeAlmanaxSourceService) SearchAnyItems(_ context.Context, _, _ string,
+) ([]dodugo.GameSearch, error) {
+	return nil, nil
+}
+
+func (service *fakeAlmanaxSourceService) GetItemType(_ string) amqp.ItemType {
+	return amqp.ItemType_RESOURCE_TYPE
+}
+
+func TestAlmanaxCalendarRequest(t *testing.T) {
+	location, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+
+	// Late in the evening in UTC, already the next day in Paris.
+	start := time.Date(2026, time.October, 17, 23, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		request  *amqp.EncyclopediaAlmanaxCalendarRequest
+		status   amqp.RabbitMQMessage_Status
+		expected int
+	}{
+		{
+			name: "every day of the range",
+			request: &amqp.EncyclopediaAlmanaxCalendarRequest{
+				Start: timestamppb.New(start),
+				End:   timestamppb.New(start.AddDate(0, 0, 2)),
+			},
+			status:   amqp.RabbitMQMessage_SUCCESS,
+			expected: 3,
+		},
+		{
+			name: "reversed range",
+			request: &amqp.EncyclopediaAlmanaxCalendarRequest{
+				Start: timestamppb.New(start),
+				End:   timestamppb.New(start.AddDate(0, 0, -2)),
+			},
+			status: amqp.RabbitMQMessage_FAILED,
+		},
+		{
+			name:    "missing end",
+			request: &amqp.EncyclopediaAlmanaxCalendarRequest{Start: timestamppb.New(start)},
+			status:  amqp.RabbitMQMessage_FAILED,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			broker := fakeBroker{}
+			sourceService := fakeAlmanaxSourceService{}
+			service := Impl{broker: &broker, sourceService: &sourceService,
+				almanaxService: &fakeAlmanaxService{location: location}}
+
+			service.consume(amqp.Context{Context: context.Background()}, &amqp.RabbitMQMessage{
+				Type:                               amqp.RabbitMQMessage_ENCYCLOPEDIA_ALMANAX_CALENDAR_REQUEST,
+				Language:                           amqp.Language_FR,
+				EncyclopediaAlmanaxCalendarRequest: test.request,
+			})
+
+			if len(broker.replies) != 1 {
+				t.Fatalf("expected a single reply, got %v", len(broker.replies))
+			}
+			reply := broker.replies[0]
+			if reply.Type != amqp.RabbitMQMessage_ENCYCLOPEDIA_ALMANAX_CALENDAR_ANSWER || reply.Status != test.status {
+				t.Fatalf("expected a %v almanax calendar answer, got %v %v", test.status, reply.Type, reply.Status)
+			}
+			if test.status != amqp.RabbitMQMessage_SUCCESS {
+				return
+			}
+
+			if sourceService.start.Day() != 18 {
+				t.Errorf("expected the range to start on the local day, got %v", sourceService.start)
+			}
+			if almanaxes := reply.EncyclopediaAlmanaxCalendarAnswer.Almanaxes; len(almanaxes) != test.expected {
+				t.Errorf("expected %v almanaxes, got %v", test.expected, len(almanaxes))
+			}
+		})
+	}
+}
+
+func TestAlmanaxResourceRequest(t *testing.T) {
+	start := time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		request  *amqp.EncyclopediaAlmanaxResourceRequest
+		status   amqp.RabbitMQMessage_Status
+		expected []string
+	}{
+		{
+			name: "sorted by date",
+			request: &amqp.EncyclopediaAlmanaxResourceRequest{
+				Start: timestamppb.New(start),
+				End:   timestamppb.New(start.AddDate(0, 0, 2)),
+			},
+			status:   amqp.RabbitMQMessage_SUCCESS,
+			expected: []string{"0", "1"},
+		},
+		{
+			name: "sorted by quantity",
+			request: &amqp.EncyclopediaAlmanaxResourceRequest{
+				Sort:  amqp.EncyclopediaAlmanaxResourceRequest_QUANTITY,
+				Start: timestamppb.New(start),
+				End:   timestamppb.New(start.AddDate(0, 0, 2)),
+			},
+			status:   amqp.RabbitMQMessage_SUCCESS,
+			expected: []string{"1", "0"},
+		},
+		{
+			name:    "missing end",
+			request: &amqp.EncyclopediaAlmanaxResourceRequest{Start: timestamppb.New(start)},
+			status:  amqp.RabbitMQMessage_FAILED,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			broker := fakeBroker{}
+			service := Impl{broker: &broker, sourceService: &fakeAlmanaxSourceService{},
+				almanaxService: &fakeAlmanaxService{location: time.UTC}}
+
+			service.consume(amqp.Context{Context: context.Background()}, &amqp.RabbitMQMessage{
+				Type:                               amqp.RabbitMQMessage_ENCYCLOPEDIA_ALMANAX_RESOURCE_REQUEST,
+				Language:                           amqp.Language_FR,
+				EncyclopediaAlmanaxResourceRequest: test.request,
+			})
+
+			if len(broker.replies) != 1 {
+				t.Fatalf("expected a single reply, got %v", len(broker.replies))
+			}
+			reply := broker.replies[0]
+			if reply.Type != amqp.RabbitMQMessage_ENCYCLOPEDIA_ALMANAX_RESOURCE_ANSWER || reply.Status != test.status {
+				t.Fatalf("expected a %v almanax resource answer, got %v %v", test.status, reply.Type, reply.Status)
+			}
+			if test.status != amqp.RabbitMQMessage_SUCCESS {
+				return
+			}
+
+			answer := reply.EncyclopediaAlmanaxResourceAnswer
+			if answer.Reward != 300 {
+				t.Errorf("expected a reward of 300, got %v", answer.Reward)
+			}
+			// Tribute 0 is needed on two days, tribute 1 on a single one.
+			expectedDates := map[string]int{"0": 2, "1": 1}
+			itemIDs := make([]string, 0, len(answer.Tributes))
+			for _, tribute := range answer.Tributes {
+				itemIDs = append(itemIDs, tribute.ItemId)
+				if tribute.ItemIcon != "icon" || len(tribute.Dates) != expectedDates[tribute.ItemId] {
+					t.Errorf("expected icon and one date per day, got %+v", tribute)
+				}
+			}
+			if !slices.Equal(itemIDs, test.expected) {
+				t.Errorf("expected tributes %v, got %v", test.expected, itemIDs)
+			}
+		})
+	}
+}
+
+func TestAlmanaxTributeRequest(t *testing.T) {
+	start := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+	almanaxService := fakeAlmanaxService{
+		location: time.UTC,
+		tributeDates: map[int32][]time.Time{
+			0: {start, start.AddDate(0, 0, 2), start.AddDate(0, 0, 4)},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		request  *amqp.EncyclopediaAlmanaxTributeRequest
+		status   amqp.RabbitMQMessage_Status
+		itemName string
+		expected int
+		page     int64
+		pages    int64
+	}{
+		{
+			name: "last page of the days requiring the item",
+			request: &amqp.EncyclopediaAlmanaxTributeRequest{
+				Item:   &amqp.EncyclopediaItemRequest{Query: "0", IsID: true},
+				Offset: 2,
+				Size:   2,
+			},
+			status:   amqp.RabbitMQMessage_SUCCESS,
+			itemName: "tribute 0",
+			expected: 1,
+			page:     1,
+			pages:    2,
+		},
+		{
+			name: "item not found",
+			request: &amqp.EncyclopediaAlmanaxTributeRequest{
+				Item: &amqp.EncyclopediaItemRequest{Query: "dofus"},
+				Size: 2,
+			},
+			status: amqp.RabbitMQMessage_SUCCESS,
+		},
+		{
+			name: "missing size",
+			request: &amqp.EncyclopediaAlmanaxTributeRequest{
+				Item: &amqp.EncyclopediaItemRequest{Query: "0", IsID: true},
+			},
+			status: amqp.RabbitMQMessage_FAILED,
+		},
+		{
+			name: "negative offset",
+			request: &amqp.EncyclopediaAlmanaxTributeRequest{
+				Item:   &amqp.EncyclopediaItemRequest{Query: "0", IsID: true},
+				Offset: -1,
+				Size:   2,
+			},
+			status: amqp.RabbitMQMessage_FAILED,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			broker := fakeBroker{}
+			service := Impl{broker: &broker, sourceService: &fakeAlmanaxSourceService{},
+				almanaxService: &almanaxService}
+
+			service.consume(amqp.Context{Context: context.Background()}, &amqp.RabbitMQMessage{
+				Type:                              amqp.RabbitMQMessage_ENCYCLOPEDIA_ALMANAX_TRIBUTE_REQUEST,
+				Language:                          amqp.Language_FR,
+				EncyclopediaAlmanaxTributeRequest: test.request,
+			})
+
+			if len(broker.replies) != 1 {
+				t.Fatalf("expected a single reply, got %v", len(broker.replies))
+			}
+			reply := broker.replies[0]
+			if reply.Type != amqp.RabbitMQMessage_ENCYCLOPEDIA_ALMANAX_TRIBUTE_ANSWER || reply.Status != test.status {
+				t.Fatalf("expected a %v almanax tribute answer, got %v %v", test.status, reply.Type, reply.Status)
+			}
+			if test.status != amqp.RabbitMQMessage_SUCCESS {
+				return
+			}
+
+			answer := reply.EncyclopediaAlmanaxTributeAnswer
+			if answer.ItemName != test.itemName || len(answer.Almanaxes) != test.expected {
+				t.Errorf("expected %v almanaxes of %q, got %v of %q",
+					test.expected, test.itemName, len(answer.Almanaxes), answer.ItemName)
+			}
+			if answer.Page != test.page || answer.Pages != test.pages {
+				t.Errorf("expected page %v out of %v, got %v out of %v",
+					test.page, test.pages, answer.Page, answer.Pages)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package encyclopedias
+
+import (
+	"context"
+
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+	"github.com/kaellybot/kaelly-encyclopedia/models/mappers"
+	"github.com/kaellybot/kaelly-encyclopedia/services/sources"
+	"github.com/rs/zerolog/log"
+)
+
+func (service *Impl) buildRequest(ctx amqp.Context, message *amqp.RabbitMQMessage) {
+	request := message.EncyclopediaBuildRequest
+	lg := mappers.MapLanguage(message.Language)
+	if !isValidBuildRequest(request) {
+		service.replyWithFailedAnswer(ctx, amqp.RabbitMQMessage_ENCYCLOPEDIA_BUILD_ANSWER,
+			message.Language)
+		return
+	}
+
+	log.Info().Str(constants.LogCorrelationID, ctx.CorrelationID).
+		Int(constants.LogEntityCount, len(request.GetEquipmentIds())).
+		Msgf("Encyclopedia Build Request received")
+
+	buildRequest, err := mappers.MapBuildRequest(request)
+	if err != nil {
+		log.Error().Err(err).
+			Str(constants.LogCorrelationID, ctx.CorrelationID).
+			Msgf("Error while converting equipment IDs, returning failed request")
+		service.replyWithFailedAnswer(ctx, amqp.RabbitMQMessage_ENCYCLOPEDIA_BUILD_ANSWER,
+			message.Language)
+		return
+	}
+
+	trackedCtx := sources.WithSourceTracking(sources.WithGame(ctx, message.Game))
+	build, err := service.SimulateBuild(trackedCtx, buildRequest, ctx.CorrelationID, lg)
+	if err != nil {
+		log.Error().Err(err).
+			Str(constants.LogCorrelationID, ctx.CorrelationID).
+			Msgf("Error while simulating build, returning failed request")
+		service.replyWithFailedAnswer(ctx, amqp.RabbitMQMessage_ENCYCLOPEDIA_BUILD_ANSWER,
+			message.Language)
+		return
+	}
+
+	response := mappers.MapBuild(build, sources.GetServingSource(trackedCtx), message.Language)
+	service.replyWithSuceededAnswer(ctx, response)
+}
+
+func (service *Impl) SimulateBuild(ctx context.Context, request constants.BuildRequest, correlationID,
+	lg string) (*constants.Build, error) {
+	return service.buildService.Simulate(ctx, request, correlationID, lg)
+}
+
+func isValidBuildRequest(request *amqp.EncyclopediaBuildRequest) bool {
+	return request != nil
+}
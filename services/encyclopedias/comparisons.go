@@ -0,0 +1,86 @@
+package encyclopedias
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/dofusdude/dodugo"
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+	"github.com/kaellybot/kaelly-encyclopedia/models/mappers"
+	"github.com/kaellybot/kaelly-encyclopedia/services/sources"
+	"github.com/rs/zerolog/log"
+)
+
+func (service *Impl) comparisonRequest(ctx amqp.Context, message *amqp.RabbitMQMessage) {
+	request := message.EncyclopediaComparisonRequest
+	lg := mappers.MapLanguage(message.Language)
+	if !isValidComparisonRequest(request) {
+		service.replyWithFailedAnswer(ctx, amqp.RabbitMQMessage_ENCYCLOPEDIA_COMPARISON_ANSWER,
+			message.Language)
+		return
+	}
+
+	log.Info().Str(constants.LogCorrelationID, ctx.CorrelationID).
+		Int(constants.LogEntityCount, len(request.GetItems())).
+		Msgf("Encyclopedia Comparison Request received")
+
+	trackedCtx := sources.WithSourceTracking(sources.WithGame(ctx, message.Game))
+	comparison, err := service.CompareEquipments(trackedCtx, request.GetItems(),
+		mappers.MapCharacterProfile(request.GetProfile()), ctx.CorrelationID, lg)
+	if err != nil {
+		log.Error().Err(err).
+			Str(constants.LogCorrelationID, ctx.CorrelationID).
+			Msgf("Error while comparing equipments, returning failed request")
+		service.replyWithFailedAnswer(ctx, amqp.RabbitMQMessage_ENCYCLOPEDIA_COMPARISON_ANSWER,
+			message.Language)
+		return
+	}
+
+	response := mappers.MapEquipmentComparisonAnswer(comparison, sources.GetServingSource(trackedCtx),
+		message.Language)
+	service.replyWithSuceededAnswer(ctx, response)
+}
+
+func (service *Impl) CompareEquipments(ctx context.Context, requests []*amqp.EncyclopediaItemRequest,
+	profile *constants.CharacterProfile, correlationID, lg string) (*constants.EquipmentComparison, error) {
+	if len(requests) < constants.MinComparedEquipments || len(requests) > constants.MaxComparedEquipments {
+		return nil, ErrComparisonSize
+	}
+
+	items := make([]*dodugo.Weapon, 0, len(requests))
+	for _, request := range requests {
+		item, err := service.getComparedEquipment(ctx, request, lg)
+		if err != nil {
+			log.Error().Err(err).
+				Str(constants.LogCorrelationID, correlationID).
+				Str(constants.LogQueryID, request.Query).
+				Msgf("Error while retrieving equipment to compare")
+			return nil, fmt.Errorf("cannot retrieve equipment '%v': %w", request.Query, err)
+		}
+
+		items = append(items, item)
+	}
+
+	return mappers.MapEquipmentComparison(items, profile, lg, service.equipmentService), nil
+}
+
+func (service *Impl) getComparedEquipment(ctx context.Context, request *amqp.EncyclopediaItemRequest,
+	lg string) (*dodugo.Weapon, error) {
+	if !request.GetIsID() {
+		return service.sourceService.GetEquipmentByQuery(ctx, request.Query, lg)
+	}
+
+	ankamaID, err := strconv.ParseInt(request.Query, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.sourceService.GetEquipmentByID(ctx, ankamaID, lg)
+}
+
+func isValidComparisonRequest(request *amqp.EncyclopediaComparisonRequest) bool {
+	return request != nil && !slices.Contains(request.GetItems(), nil)
+}
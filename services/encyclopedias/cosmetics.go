@@ -6,6 +6,7 @@ import (
 
 	amqp "github.com/kaellybot/kaelly-amqp"
 	"github.com/kaellybot/kaelly-encyclopedia/models/mappers"
+	"github.com/kaellybot/kaelly-encyclopedia/services/sources"
 )
 
 func (service *Impl) getCosmeticByID(ctx context.Context, id int64, correlationID,
@@ -16,8 +17,9 @@ func (service *Impl) getCosmeticByID(ctx context.Context, id int64, correlationI
 		return nil, err
 	}
 
-	ingredients := service.getIngredients(ctx, cosmetic.GetRecipe(), correlationID, lg)
-	return mappers.MapEquipment(query, cosmetic, ingredients, service.equipmentService), nil
+	ingredients := service.craftService.GetIngredients(ctx, cosmetic.GetRecipe(), correlationID, lg)
+	return mappers.MapEquipment(query, cosmetic, ingredients, sources.GetServingSource(ctx),
+		service.equipmentService), nil
 }
 
 func (service *Impl) getCosmeticByQuery(ctx context.Context, query, correlationID,
@@ -27,6 +29,7 @@ func (service *Impl) getCosmeticByQuery(ctx context.Context, query, correlationI
 		return nil, err
 	}
 
-	ingredients := service.getIngredients(ctx, cosmetic.GetRecipe(), correlationID, lg)
-	return mappers.MapEquipment(query, cosmetic, ingredients, service.equipmentService), nil
+	ingredients := service.craftService.GetIngredients(ctx, cosmetic.GetRecipe(), correlationID, lg)
+	return mappers.MapEquipment(query, cosmetic, ingredients, sources.GetServingSource(ctx),
+		service.equipmentService), nil
 }
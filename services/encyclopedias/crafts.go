@@ -0,0 +1,113 @@
+package encyclopedias
+
+import (
+	"errors"
+	"fmt"
+
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+	"github.com/kaellybot/kaelly-encyclopedia/models/mappers"
+	"github.com/kaellybot/kaelly-encyclopedia/services/sources"
+	"github.com/rs/zerolog/log"
+)
+
+func (service *Impl) craftRequest(ctx amqp.Context, message *amqp.RabbitMQMessage) {
+	request := message.EncyclopediaCraftRequest
+	lg := mappers.MapLanguage(message.Language)
+	if !isValidCraftRequest(request) {
+		service.replyWithFailedAnswer(ctx, amqp.RabbitMQMessage_ENCYCLOPEDIA_CRAFT_ANSWER,
+			message.Language)
+		return
+	}
+
+	log.Info().Str(constants.LogCorrelationID, ctx.CorrelationID).
+		Str(constants.LogQueryID, request.Query).
+		Str(constants.LogQueryType, request.GetType().String()).
+		Msgf("Encyclopedia Craft Request received")
+
+	trackedCtx := sources.WithSourceTracking(sources.WithGame(ctx, message.Game))
+	itemType, itemID, _, err := service.getItemFromRequest(trackedCtx, request.Query, request.GetIsID(),
+		request.GetType(), lg)
+	var tree *constants.CraftTree
+	if err == nil {
+		tree, err = service.craftService.GetCraftTree(trackedCtx, itemType, itemID,
+			max(request.GetQuantity(), defaultCraftQuantity), ctx.CorrelationID, lg)
+	}
+
+	if err != nil && !errors.Is(err, sources.ErrNotFound) {
+		log.Error().Err(err).
+			Str(constants.LogCorrelationID, ctx.CorrelationID).
+			Str(constants.LogQueryID, request.Query).
+			Str(constants.LogQueryType, request.GetType().String()).
+			Msgf("Error while retrieving crafting tree, returning failed request")
+		service.replyWithFailedAnswer(ctx, amqp.RabbitMQMessage_ENCYCLOPEDIA_CRAFT_ANSWER,
+			message.Language)
+		return
+	}
+
+	response := mappers.MapCraftTree(request.Query, tree, sources.GetServingSource(trackedCtx),
+		message.Language)
+	service.replyWithSuceededAnswer(ctx, response)
+}
+
+func (service *Impl) usageRequest(ctx amqp.Context, message *amqp.RabbitMQMessage) {
+	request := message.EncyclopediaUsageRequest
+	lg := mappers.MapLanguage(message.Language)
+	if !isValidUsageRequest(request) {
+		service.replyWithFailedAnswer(ctx, amqp.RabbitMQMessage_ENCYCLOPEDIA_USAGE_ANSWER,
+			message.Language)
+		return
+	}
+
+	log.Info().Str(constants.LogCorrelationID, ctx.CorrelationID).
+		Str(constants.LogQueryID, request.Query).
+		Str(constants.LogQueryType, request.GetType().String()).
+		Msgf("Encyclopedia Usage Request received")
+
+	trackedCtx := sources.WithSourceTracking(sources.WithGame(ctx, message.Game))
+	_, itemID, itemName, err := service.getItemFromRequest(trackedCtx, request.Query, request.GetIsID(),
+		request.GetType(), lg)
+	if err != nil {
+		if errors.Is(err, sources.ErrNotFound) {
+			response := mappers.MapItemUsages(request.Query, "", "", nil,
+				sources.GetServingSource(trackedCtx), message.Language)
+			service.replyWithSuceededAnswer(ctx, response)
+			return
+		}
+
+		log.Error().Err(err).
+			Str(constants.LogCorrelationID, ctx.CorrelationID).
+			Str(constants.LogQueryID, request.Query).
+			Str(constants.LogQueryType, request.GetType().String()).
+			Msgf("Error while retrieving ingredient, returning failed request")
+		service.replyWithFailedAnswer(ctx, amqp.RabbitMQMessage_ENCYCLOPEDIA_USAGE_ANSWER,
+			message.Language)
+		return
+	}
+
+	usages, err := service.craftService.GetItemUsages(trackedCtx, itemID, request.GetMinLevel(),
+		request.GetMaxLevel(), request.GetOffset(), request.GetSize(), lg)
+	if err != nil {
+		log.Error().Err(err).
+			Str(constants.LogCorrelationID, ctx.CorrelationID).
+			Str(constants.LogQueryID, request.Query).
+			Str(constants.LogQueryType, request.GetType().String()).
+			Msgf("Error while retrieving item usages, returning failed request")
+		service.replyWithFailedAnswer(ctx, amqp.RabbitMQMessage_ENCYCLOPEDIA_USAGE_ANSWER,
+			message.Language)
+		return
+	}
+
+	response := mappers.MapItemUsages(request.Query, fmt.Sprintf("%v", itemID), itemName, usages,
+		sources.GetServingSource(trackedCtx), message.Language)
+	service.replyWithSuceededAnswer(ctx, response)
+}
+
+// isValidCraftRequest requires the item type when the item is requested by ID.
+func isValidCraftRequest(request *amqp.EncyclopediaCraftRequest) bool {
+	return request != nil && (!request.GetIsID() || request.GetType() != amqp.ItemType_ANY_ITEM_TYPE)
+}
+
+func isValidUsageRequest(request *amqp.EncyclopediaUsageRequest) bool {
+	return request != nil
+}
@@ -0,0 +1,126 @@
+package encyclopedias
+
+import (
+	"context"
+	"testing"
+
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+	"github.com/kaellybot/kaelly-encyclopedia/services/crafts"
+)
+
+type fakeBroker struct {
+	amqp.MessageBroker
+	replies []*amqp.RabbitMQMessage
+}
+
+func (broker *fakeBroker) Reply(msg *amqp.RabbitMQMessage, _, _ string) error {
+	broker.replies = append(broker.replies, msg)
+	return nil
+}
+
+type fakeCraftService struct {
+	crafts.Service
+	usages map[int64][]constants.ItemUsage
+	err    error
+}
+
+func (service *fakeCraftService) GetItemUsages(_ context.Context, ingredientID, _, _, offset, size int64,
+	_ string) (*constants.ItemUsages, error) {
+	if service.err != nil {
+		return nil, service.err
+	}
+
+	usages := service.usages[ingredientID]
+	return &constants.ItemUsages{
+		Usages: usages[offset:min(offset+size, int64(len(usages)))],
+		Offset: offset,
+		Total:  int64(len(usages)),
+	}, nil
+}
+
+func TestUsageRequest(t *testing.T) {
+	craftService := fakeCraftService{
+		usages: map[int64][]constants.ItemUsage{
+			289: {
+				{ID: "1", Name: "Coiffe du Bouftou", Type: amqp.ItemType_EQUIPMENT_TYPE, Level: 10, Quantity: 4},
+				{ID: "2", Name: "Cape du Bouftou", Type: amqp.ItemType_EQUIPMENT_TYPE, Level: 12, Quantity: 5},
+				{ID: "3", Name: "Bottes du Bouftou", Type: amqp.ItemType_EQUIPMENT_TYPE, Level: 14, Quantity: 3},
+			},
+			1: {
+				{ID: "4", Name: "Anneau Gelé", Type: amqp.ItemType_EQUIPMENT_TYPE, Level: 120, Quantity: 1},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		request  *amqp.EncyclopediaUsageRequest
+		err      error
+		status   amqp.RabbitMQMessage_Status
+		expected []string
+		total    int64
+	}{
+		{
+			name:     "usages of an ingredient by ID, paginated",
+			request:  &amqp.EncyclopediaUsageRequest{Query: "289", IsID: true, Offset: 2, Size: 2},
+			status:   amqp.RabbitMQMessage_SUCCESS,
+			expected: []string{"3"},
+			total:    3,
+		},
+		{
+			name:    "invalid ID",
+			request: &amqp.EncyclopediaUsageRequest{Query: "bouftou", IsID: true},
+			status:  amqp.RabbitMQMessage_FAILED,
+		},
+		{
+			name:    "usage index not built",
+			request: &amqp.EncyclopediaUsageRequest{Query: "289", IsID: true},
+			err:     crafts.ErrIndexNotBuilt,
+			status:  amqp.RabbitMQMessage_FAILED,
+		},
+		{
+			name:   "missing request",
+			status: amqp.RabbitMQMessage_FAILED,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			broker := fakeBroker{}
+			craftService.err = test.err
+			service := Impl{broker: &broker, craftService: &craftService}
+
+			service.consume(amqp.Context{Context: context.Background()}, &amqp.RabbitMQMessage{
+				Type:                     amqp.RabbitMQMessage_ENCYCLOPEDIA_USAGE_REQUEST,
+				Language:                 amqp.Language_FR,
+				EncyclopediaUsageRequest: test.request,
+			})
+
+			if len(broker.replies) != 1 {
+				t.Fatalf("expected a single reply, got %v", len(broker.replies))
+			}
+			reply := broker.replies[0]
+			if reply.Type != amqp.RabbitMQMessage_ENCYCLOPEDIA_USAGE_ANSWER || reply.Status != test.status {
+				t.Fatalf("expected a %v usage answer, got %v %v", test.status, reply.Type, reply.Status)
+			}
+			if test.status != amqp.RabbitMQMessage_SUCCESS {
+				return
+			}
+
+			answer := reply.EncyclopediaUsageAnswer
+			if answer.ItemId != test.request.Query || answer.Total != test.total {
+				t.Errorf("expected item %v with %v usages, got %v with %v usages",
+					test.request.Query, test.total, answer.ItemId, answer.Total)
+			}
+			if len(answer.Usages) != len(test.expected) {
+				t.Fatalf("expected %v usages, got %v", len(test.expected), len(answer.Usages))
+			}
+			for i, usage := range answer.Usages {
+				if usage.Id != test.expected[i] {
+					t.Errorf("expected usage %v at position %v, got %v", test.expected[i], i, usage.Id)
+				}
+			}
+		})
+	}
+}
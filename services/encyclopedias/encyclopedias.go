@@ -4,6 +4,8 @@ import (
 	amqp "github.com/kaellybot/kaelly-amqp"
 	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
 	"github.com/kaellybot/kaelly-encyclopedia/services/almanaxes"
+	"github.com/kaellybot/kaelly-encyclopedia/services/builds"
+	"github.com/kaellybot/kaelly-encyclopedia/services/crafts"
 	"github.com/kaellybot/kaelly-encyclopedia/services/equipments"
 	"github.com/kaellybot/kaelly-encyclopedia/services/sets"
 	"github.com/kaellybot/kaelly-encyclopedia/services/sources"
@@ -11,10 +13,12 @@ import (
 )
 
 func New(broker amqp.MessageBroker, sourceService sources.Service,
-	almanaxService almanaxes.Service, equipmentService equipments.Service,
-	setService sets.Service) *Impl {
+	almanaxService almanaxes.Service, buildService builds.Service, craftService crafts.Service,
+	equipmentService equipments.Service, setService sets.Service) *Impl {
 	service := Impl{
 		sourceService:    sourceService,
+		buildService:     buildService,
+		craftService:     craftService,
 		almanaxService:   almanaxService,
 		equipmentService: equipmentService,
 		setService:       setService,
@@ -34,6 +38,10 @@ func New(broker amqp.MessageBroker, sourceService sources.Service,
 			GetItemByID:    service.getItemByID,
 			GetItemByQuery: service.getItemByQuery,
 		},
+		amqp.ItemType_CONSUMABLE_TYPE: {
+			GetItemByID:    service.getConsumableByID,
+			GetItemByQuery: service.getConsumableByQuery,
+		},
 		amqp.ItemType_COSMETIC_TYPE: {
 			GetItemByID:    service.getCosmeticByID,
 			GetItemByQuery: service.getCosmeticByQuery,
@@ -46,21 +54,20 @@ func New(broker amqp.MessageBroker, sourceService sources.Service,
 			GetItemByID:    service.getMountByID,
 			GetItemByQuery: service.getMountByQuery,
 		},
+		amqp.ItemType_QUEST_ITEM_TYPE: {
+			GetItemByID:    service.getQuestItemByID,
+			GetItemByQuery: service.getQuestItemByQuery,
+		},
+		amqp.ItemType_RESOURCE_TYPE: {
+			GetItemByID:    service.getResourceByID,
+			GetItemByQuery: service.getResourceByQuery,
+		},
 		amqp.ItemType_SET_TYPE: {
 			GetItemByID:    service.getSetByID,
 			GetItemByQuery: service.getSetByQuery,
 		},
 	}
 
-	//nolint:exhaustive // Ingredient types possibility is exhaustive here.
-	service.getIngredientByFuncs = map[amqp.ItemType]getIngredientByIDFunc{
-		amqp.ItemType_ANY_ITEM_TYPE:   nil,
-		amqp.ItemType_CONSUMABLE_TYPE: service.getConsumableIngredientByID,
-		amqp.ItemType_EQUIPMENT_TYPE:  service.getEquipmentIngredientByID,
-		amqp.ItemType_QUEST_ITEM_TYPE: service.getQuestItemIngredientByID,
-		amqp.ItemType_RESOURCE_TYPE:   service.getResourceIngredientByID,
-	}
-
 	return &service
 }
 
@@ -87,10 +94,26 @@ func (service *Impl) consume(ctx amqp.Context, message *amqp.RabbitMQMessage) {
 		service.almanaxResourceRequest(ctx, message)
 	case amqp.RabbitMQMessage_ENCYCLOPEDIA_ALMANAX_EFFECT_REQUEST:
 		service.almanaxEffectRequest(ctx, message)
+	case amqp.RabbitMQMessage_ENCYCLOPEDIA_ALMANAX_CALENDAR_REQUEST:
+		service.almanaxCalendarRequest(ctx, message)
+	case amqp.RabbitMQMessage_ENCYCLOPEDIA_ALMANAX_TRIBUTE_REQUEST:
+		service.almanaxTributeRequest(ctx, message)
 	case amqp.RabbitMQMessage_ENCYCLOPEDIA_LIST_REQUEST:
 		service.listRequest(ctx, message)
 	case amqp.RabbitMQMessage_ENCYCLOPEDIA_ITEM_REQUEST:
 		service.itemRequest(ctx, message)
+	case amqp.RabbitMQMessage_ENCYCLOPEDIA_CRAFT_REQUEST:
+		service.craftRequest(ctx, message)
+	case amqp.RabbitMQMessage_ENCYCLOPEDIA_USAGE_REQUEST:
+		service.usageRequest(ctx, message)
+	case amqp.RabbitMQMessage_ENCYCLOPEDIA_SET_BONUS_REQUEST:
+		service.setBonusRequest(ctx, message)
+	case amqp.RabbitMQMessage_ENCYCLOPEDIA_COMPARISON_REQUEST:
+		service.comparisonRequest(ctx, message)
+	case amqp.RabbitMQMessage_ENCYCLOPEDIA_BUILD_REQUEST:
+		service.buildRequest(ctx, message)
+	case amqp.RabbitMQMessage_ENCYCLOPEDIA_EQUIPMENT_SEARCH_REQUEST:
+		service.equipmentSearchRequest(ctx, message)
 	default:
 		log.Warn().
 			Str(constants.LogCorrelationID, ctx.CorrelationID).
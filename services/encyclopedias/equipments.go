@@ -16,14 +16,16 @@ func (service *Impl) getEquipmentByID(ctx context.Context, id int64, correlation
 	equipment, err := service.sourceService.GetEquipmentByID(ctx, id, lg)
 	if err != nil {
 		if errors.Is(err, sources.ErrNotFound) {
-			return mappers.MapEquipment(query, nil, nil, service.equipmentService), nil
+			return mappers.MapEquipment(query, nil, nil, sources.GetServingSource(ctx),
+				service.equipmentService), nil
 		}
 
 		return nil, err
 	}
 
-	ingredients := service.getIngredients(ctx, equipment.GetRecipe(), correlationID, lg)
-	return mappers.MapEquipment(query, equipment, ingredients, service.equipmentService), nil
+	ingredients := service.craftService.GetIngredients(ctx, equipment.GetRecipe(), correlationID, lg)
+	return mappers.MapEquipment(query, equipment, ingredients, sources.GetServingSource(ctx),
+		service.equipmentService), nil
 }
 
 func (service *Impl) getEquipmentByQuery(ctx context.Context, query, correlationID,
@@ -31,12 +33,14 @@ func (service *Impl) getEquipmentByQuery(ctx context.Context, query, correlation
 	equipment, err := service.sourceService.GetEquipmentByQuery(ctx, query, lg)
 	if err != nil {
 		if errors.Is(err, sources.ErrNotFound) {
-			return mappers.MapEquipment(query, nil, nil, service.equipmentService), nil
+			return mappers.MapEquipment(query, nil, nil, sources.GetServingSource(ctx),
+				service.equipmentService), nil
 		}
 
 		return nil, err
 	}
 
-	ingredients := service.getIngredients(ctx, equipment.GetRecipe(), correlationID, lg)
-	return mappers.MapEquipment(query, equipment, ingredients, service.equipmentService), nil
+	ingredients := service.craftService.GetIngredients(ctx, equipment.GetRecipe(), correlationID, lg)
+	return mappers.MapEquipment(query, equipment, ingredients, sources.GetServingSource(ctx),
+		service.equipmentService), nil
 }
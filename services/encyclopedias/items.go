@@ -2,12 +2,15 @@ package encyclopedias
 
 import (
 	"context"
+	"slices"
 	"strconv"
 
+	"github.com/dofusdude/dodugo"
 	amqp "github.com/kaellybot/kaelly-amqp"
 	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
 	"github.com/kaellybot/kaelly-encyclopedia/models/mappers"
 	"github.com/kaellybot/kaelly-encyclopedia/services/sources"
+	"github.com/kaellybot/kaelly-encyclopedia/utils/rankings"
 	"github.com/rs/zerolog/log"
 )
 
@@ -38,6 +41,7 @@ func (service *Impl) itemRequest(ctx amqp.Context, message *amqp.RabbitMQMessage
 
 	var reply *amqp.EncyclopediaItemAnswer
 	var err error
+	trackedCtx := sources.WithSourceTracking(sources.WithGame(ctx, message.Game))
 	if request.GetIsID() {
 		ankamaID, errID := strconv.ParseInt(request.Query, 10, 32)
 		if errID != nil {
@@ -51,9 +55,9 @@ func (service *Impl) itemRequest(ctx amqp.Context, message *amqp.RabbitMQMessage
 			return
 		}
 
-		reply, err = funcs.GetItemByID(ctx, ankamaID, ctx.CorrelationID, lg)
+		reply, err = funcs.GetItemByID(trackedCtx, ankamaID, ctx.CorrelationID, lg)
 	} else {
-		reply, err = funcs.GetItemByQuery(ctx, request.Query, ctx.CorrelationID, lg)
+		reply, err = funcs.GetItemByQuery(trackedCtx, request.Query, ctx.CorrelationID, lg)
 	}
 
 	if err != nil {
@@ -67,6 +71,7 @@ func (service *Impl) itemRequest(ctx amqp.Context, message *amqp.RabbitMQMessage
 		return
 	}
 
+	service.sourceService.RecordItemRequest(trackedCtx)
 	response := mappers.MapItem(reply, message.Language)
 	service.replyWithSuceededAnswer(ctx, response)
 }
@@ -84,11 +89,11 @@ func (service *Impl) getItemByQuery(ctx context.Context, query, correlationID,
 	}
 
 	if len(values) == 0 {
-		return mappers.MapNoItem(query, amqp.ItemType_ANY_ITEM_TYPE), nil
+		return mappers.MapNoItem(query, amqp.ItemType_ANY_ITEM_TYPE, sources.GetServingSource(ctx)), nil
 	}
 
-	// We trust the omnisearch by taking the first one in the list
-	item := values[0]
+	item := values[rankings.BestMatch(query, values,
+		func(item dodugo.GameSearch) string { return item.GetName() })]
 	itemType := service.sourceService.GetItemType(item.Type.GetNameId())
 	funcs, found := service.getItemByFuncs[itemType]
 	if !found {
@@ -103,6 +108,35 @@ func (service *Impl) getItemByQuery(ctx context.Context, query, correlationID,
 	return resp, nil
 }
 
+// getItemFromRequest returns the type, ID and name of an item requested by ID or query;
+// the name is unknown when the item is requested by ID. Searches are restricted to the item type if given.
+func (service *Impl) getItemFromRequest(ctx context.Context, query string, isID bool, itemType amqp.ItemType,
+	lg string) (amqp.ItemType, int64, string, error) {
+	if isID {
+		ankamaID, err := strconv.ParseInt(query, 10, 32)
+		return itemType, ankamaID, "", err
+	}
+
+	values, err := service.sourceService.SearchAnyItems(ctx, query, lg)
+	if err != nil {
+		return itemType, 0, "", err
+	}
+
+	if itemType != amqp.ItemType_ANY_ITEM_TYPE {
+		values = slices.DeleteFunc(values, func(item dodugo.GameSearch) bool {
+			return service.sourceService.GetItemType(item.Type.GetNameId()) != itemType
+		})
+	}
+
+	if len(values) == 0 {
+		return itemType, 0, "", sources.ErrNotFound
+	}
+
+	item := values[rankings.BestMatch(query, values,
+		func(item dodugo.GameSearch) string { return item.GetName() })]
+	return service.sourceService.GetItemType(item.Type.GetNameId()), int64(item.GetAnkamaId()), item.GetName(), nil
+}
+
 func isValidItemRequest(request *amqp.EncyclopediaItemRequest) bool {
 	return request != nil
 }
@@ -1,11 +1,14 @@
 package encyclopedias
 
 import (
+	"cmp"
 	"context"
+	"slices"
 
 	amqp "github.com/kaellybot/kaelly-amqp"
 	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
 	"github.com/kaellybot/kaelly-encyclopedia/models/mappers"
+	"github.com/kaellybot/kaelly-encyclopedia/services/sources"
 	"github.com/rs/zerolog/log"
 )
 
@@ -22,19 +25,8 @@ func (service *Impl) listRequest(ctx amqp.Context, message *amqp.RabbitMQMessage
 		Str(constants.LogQueryType, request.GetType().String()).
 		Msgf("Encyclopedia List Request received")
 
-	getListFunc, found := service.getListByFunc[request.Type]
-	if !found {
-		log.Error().Str(constants.LogCorrelationID, ctx.CorrelationID).
-			Str(constants.LogQueryID, request.Query).
-			Str(constants.LogQueryType, request.GetType().String()).
-			Msgf("Error while handling encyclopedia list query type, returning failed request")
-		service.replyWithFailedAnswer(ctx, amqp.RabbitMQMessage_ENCYCLOPEDIA_LIST_ANSWER,
-			message.Language)
-		return
-	}
-
-	list, err := getListFunc(ctx, request.Query, ctx.CorrelationID,
-		mappers.MapLanguage(message.Language))
+	list, err := service.GetList(sources.WithGame(ctx, message.Game), request.Type, request.Query,
+		mappers.MapListFilter(request), ctx.CorrelationID, mappers.MapLanguage(message.Language))
 	if err != nil {
 		log.Error().Err(err).
 			Str(constants.LogCorrelationID, ctx.CorrelationID).
@@ -46,38 +38,119 @@ func (service *Impl) listRequest(ctx amqp.Context, message *amqp.RabbitMQMessage
 		return
 	}
 
-	response := mappers.MapList(list, message.Language)
+	response := mappers.MapList(mappers.MapListAnswer(list), message.Language)
 	service.replyWithSuceededAnswer(ctx, response)
 }
 
+func (service *Impl) GetList(ctx context.Context, listType amqp.EncyclopediaListRequest_Type,
+	query string, filter constants.ListFilter, correlationID, lg string) (*constants.List, error) {
+	getListFunc, found := service.getListByFunc[listType]
+	if !found || getListFunc == nil {
+		return nil, errUnknownQuery
+	}
+
+	entries, err := getListFunc(ctx, query, correlationID, lg)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterList(entries, filter), nil
+}
+
 func (service *Impl) getItemList(ctx context.Context, query, _,
-	lg string) (*amqp.EncyclopediaListAnswer, error) {
-	dodugoItems, err := service.sourceService.SearchAnyItems(ctx, query, lg)
+	lg string) ([]constants.ListEntry, error) {
+	dodugoItems, err := service.sourceService.AutocompleteAnyItems(ctx, query, lg)
 	if err != nil {
 		return nil, err
 	}
 
-	return mappers.MapItemList(dodugoItems), nil
+	return mappers.MapItemListEntries(dodugoItems, service.sourceService), nil
 }
 
 func (service *Impl) getSetList(ctx context.Context, query, _,
-	lg string) (*amqp.EncyclopediaListAnswer, error) {
-	dodugoSets, err := service.sourceService.SearchSets(ctx, query, lg)
+	lg string) ([]constants.ListEntry, error) {
+	dodugoSets, err := service.sourceService.AutocompleteSets(ctx, query, lg)
 	if err != nil {
 		return nil, err
 	}
 
-	return mappers.MapSetList(dodugoSets), nil
+	entries := make([]constants.ListEntry, 0, len(dodugoSets))
+	for _, set := range dodugoSets {
+		icon := service.getSetIcon(ctx, int64(set.GetAnkamaId()))
+		entries = append(entries, mappers.MapSetListEntry(set, icon))
+	}
+
+	return entries, nil
 }
 
 func (service *Impl) getAlmanaxEffectList(ctx context.Context, query, _,
-	lg string) (*amqp.EncyclopediaListAnswer, error) {
-	dodugoAlmanaxEffects, err := service.sourceService.SearchAlmanaxEffects(ctx, query, lg)
+	lg string) ([]constants.ListEntry, error) {
+	dodugoAlmanaxEffects, err := service.sourceService.AutocompleteAlmanaxEffects(ctx, query, lg)
 	if err != nil {
 		return nil, err
 	}
 
-	return mappers.MapAlmanaxEffectList(dodugoAlmanaxEffects), nil
+	return mappers.MapAlmanaxEffectListEntries(dodugoAlmanaxEffects), nil
+}
+
+// filterList applies the filter on entries ordered by relevance, then sorts and pages them.
+func filterList(entries []constants.ListEntry, filter constants.ListFilter) *constants.List {
+	filtered := make([]constants.ListEntry, 0, len(entries))
+	for _, entry := range entries {
+		if matchesListFilter(entry, filter) {
+			filtered = append(filtered, entry)
+		}
+	}
+
+	sortListEntries(filtered, filter)
+
+	offset := min(max(filter.Offset, 0), int64(len(filtered)))
+	end := int64(len(filtered))
+	if filter.Size > 0 {
+		end = min(offset+filter.Size, end)
+	}
+
+	return &constants.List{
+		Entries: filtered[offset:end],
+		Offset:  offset,
+		Total:   int64(len(filtered)),
+	}
+}
+
+func matchesListFilter(entry constants.ListEntry, filter constants.ListFilter) bool {
+	if filter.ItemType != amqp.ItemType_ANY_ITEM_TYPE && entry.Type != filter.ItemType {
+		return false
+	}
+
+	if filter.MinLevel > 0 && entry.Level < filter.MinLevel {
+		return false
+	}
+
+	return filter.MaxLevel <= 0 || entry.Level > 0 && entry.Level <= filter.MaxLevel
+}
+
+func sortListEntries(entries []constants.ListEntry, filter constants.ListFilter) {
+	var compare func(a, b constants.ListEntry) int
+	switch filter.Sort {
+	case constants.ListSortName:
+		compare = func(a, b constants.ListEntry) int { return cmp.Compare(a.Name, b.Name) }
+	case constants.ListSortLevel:
+		compare = func(a, b constants.ListEntry) int { return cmp.Compare(a.Level, b.Level) }
+	case constants.ListSortRelevance:
+		fallthrough
+	default:
+		if filter.Descending {
+			slices.Reverse(entries)
+		}
+		return
+	}
+
+	slices.SortStableFunc(entries, func(a, b constants.ListEntry) int {
+		if filter.Descending {
+			return compare(b, a)
+		}
+		return compare(a, b)
+	})
 }
 
 func isValidListRequest(request *amqp.EncyclopediaListRequest) bool {
@@ -0,0 +1,98 @@
+package encyclopedias
+
+import (
+	"slices"
+	"testing"
+
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+)
+
+func TestFilterList(t *testing.T) {
+	// Entries are ordered by relevance.
+	entries := []constants.ListEntry{
+		{ID: "1", Name: "Gelano", Type: amqp.ItemType_EQUIPMENT_TYPE, Level: 60},
+		{ID: "2", Name: "Anneau Gelé", Type: amqp.ItemType_EQUIPMENT_TYPE, Level: 120},
+		{ID: "3", Name: "Gelée Bleutée", Type: amqp.ItemType_RESOURCE_TYPE, Level: 20},
+		{ID: "4", Name: "Dragodinde Amande", Type: amqp.ItemType_MOUNT_TYPE},
+	}
+
+	tests := []struct {
+		name     string
+		filter   constants.ListFilter
+		expected []string
+		total    int64
+		offset   int64
+	}{
+		{
+			name:     "no filter keeps relevance order",
+			expected: []string{"1", "2", "3", "4"},
+			total:    4,
+		},
+		{
+			name:     "by type",
+			filter:   constants.ListFilter{ItemType: amqp.ItemType_EQUIPMENT_TYPE},
+			expected: []string{"1", "2"},
+			total:    2,
+		},
+		{
+			name:     "level range leaves entries without level out",
+			filter:   constants.ListFilter{MinLevel: 50, MaxLevel: 100},
+			expected: []string{"1"},
+			total:    1,
+		},
+		{
+			name:     "max level only",
+			filter:   constants.ListFilter{MaxLevel: 100},
+			expected: []string{"1", "3"},
+			total:    2,
+		},
+		{
+			name:     "sorted by name",
+			filter:   constants.ListFilter{Sort: constants.ListSortName},
+			expected: []string{"2", "4", "1", "3"},
+			total:    4,
+		},
+		{
+			name:     "sorted by descending level",
+			filter:   constants.ListFilter{Sort: constants.ListSortLevel, Descending: true},
+			expected: []string{"2", "1", "3", "4"},
+			total:    4,
+		},
+		{
+			name:     "descending relevance",
+			filter:   constants.ListFilter{Descending: true},
+			expected: []string{"4", "3", "2", "1"},
+			total:    4,
+		},
+		{
+			name:     "paginated",
+			filter:   constants.ListFilter{Offset: 1, Size: 2},
+			expected: []string{"2", "3"},
+			total:    4,
+			offset:   1,
+		},
+		{
+			name:     "offset out of range",
+			filter:   constants.ListFilter{Offset: 10, Size: 2},
+			expected: []string{},
+			total:    4,
+			offset:   4,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			list := filterList(slices.Clone(entries), test.filter)
+
+			ids := make([]string, 0, len(list.Entries))
+			for _, entry := range list.Entries {
+				ids = append(ids, entry.ID)
+			}
+			if !slices.Equal(ids, test.expected) || list.Total != test.total || list.Offset != test.offset {
+				t.Errorf("expected %v out of %v from %v, got %v out of %v from %v",
+					test.expected, test.total, test.offset, ids, list.Total, list.Offset)
+			}
+		})
+	}
+}
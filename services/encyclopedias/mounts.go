@@ -5,6 +5,7 @@ import (
 
 	amqp "github.com/kaellybot/kaelly-amqp"
 	"github.com/kaellybot/kaelly-encyclopedia/models/mappers"
+	"github.com/kaellybot/kaelly-encyclopedia/services/sources"
 )
 
 func (service *Impl) getMountByID(ctx context.Context, id int64, _,
@@ -14,7 +15,7 @@ func (service *Impl) getMountByID(ctx context.Context, id int64, _,
 		return nil, err
 	}
 
-	return mappers.MapMount(mount, service.equipmentService), nil
+	return mappers.MapMount(mount, sources.GetServingSource(ctx), service.equipmentService), nil
 }
 
 func (service *Impl) getMountByQuery(ctx context.Context, query, _,
@@ -24,5 +25,5 @@ func (service *Impl) getMountByQuery(ctx context.Context, query, _,
 		return nil, err
 	}
 
-	return mappers.MapMount(mount, service.equipmentService), nil
+	return mappers.MapMount(mount, sources.GetServingSource(ctx), service.equipmentService), nil
 }
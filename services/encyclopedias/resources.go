@@ -0,0 +1,72 @@
+package encyclopedias
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dofusdude/dodugo"
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/mappers"
+	"github.com/kaellybot/kaelly-encyclopedia/services/sources"
+)
+
+func (service *Impl) getConsumableByID(ctx context.Context, id int64, correlationID,
+	lg string) (*amqp.EncyclopediaItemAnswer, error) {
+	consumable, err := service.sourceService.GetConsumableByID(ctx, id, lg)
+	return service.mapResource(ctx, fmt.Sprintf("%v", id), amqp.ItemType_CONSUMABLE_TYPE,
+		consumable, err, correlationID, lg)
+}
+
+func (service *Impl) getConsumableByQuery(ctx context.Context, query, correlationID,
+	lg string) (*amqp.EncyclopediaItemAnswer, error) {
+	consumable, err := service.sourceService.GetConsumableByQuery(ctx, query, lg)
+	return service.mapResource(ctx, query, amqp.ItemType_CONSUMABLE_TYPE,
+		consumable, err, correlationID, lg)
+}
+
+func (service *Impl) getQuestItemByID(ctx context.Context, id int64, correlationID,
+	lg string) (*amqp.EncyclopediaItemAnswer, error) {
+	questItem, err := service.sourceService.GetQuestItemByID(ctx, id, lg)
+	return service.mapResource(ctx, fmt.Sprintf("%v", id), amqp.ItemType_QUEST_ITEM_TYPE,
+		questItem, err, correlationID, lg)
+}
+
+func (service *Impl) getQuestItemByQuery(ctx context.Context, query, correlationID,
+	lg string) (*amqp.EncyclopediaItemAnswer, error) {
+	questItem, err := service.sourceService.GetQuestItemByQuery(ctx, query, lg)
+	return service.mapResource(ctx, query, amqp.ItemType_QUEST_ITEM_TYPE,
+		questItem, err, correlationID, lg)
+}
+
+func (service *Impl) getResourceByID(ctx context.Context, id int64, correlationID,
+	lg string) (*amqp.EncyclopediaItemAnswer, error) {
+	resource, err := service.sourceService.GetResourceByID(ctx, id, lg)
+	return service.mapResource(ctx, fmt.Sprintf("%v", id), amqp.ItemType_RESOURCE_TYPE,
+		resource, err, correlationID, lg)
+}
+
+func (service *Impl) getResourceByQuery(ctx context.Context, query, correlationID,
+	lg string) (*amqp.EncyclopediaItemAnswer, error) {
+	resource, err := service.sourceService.GetResourceByQuery(ctx, query, lg)
+	return service.mapResource(ctx, query, amqp.ItemType_RESOURCE_TYPE,
+		resource, err, correlationID, lg)
+}
+
+func (service *Impl) mapResource(ctx context.Context, query string, itemType amqp.ItemType,
+	resource *dodugo.Resource, err error, correlationID, lg string) (*amqp.EncyclopediaItemAnswer, error) {
+	if err != nil {
+		if errors.Is(err, sources.ErrNotFound) {
+			return mappers.MapResource(query, itemType, nil, nil, sources.GetServingSource(ctx)), nil
+		}
+
+		return nil, err
+	}
+
+	if resource == nil {
+		return mappers.MapResource(query, itemType, nil, nil, sources.GetServingSource(ctx)), nil
+	}
+
+	ingredients := service.craftService.GetIngredients(ctx, resource.GetRecipe(), correlationID, lg)
+	return mappers.MapResource(query, itemType, resource, ingredients, sources.GetServingSource(ctx)), nil
+}
@@ -0,0 +1,160 @@
+package encyclopedias
+
+import (
+	"cmp"
+	"context"
+	"sort"
+	"strings"
+
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+	"github.com/kaellybot/kaelly-encyclopedia/models/mappers"
+	"github.com/kaellybot/kaelly-encyclopedia/services/sources"
+	"github.com/kaellybot/kaelly-encyclopedia/utils/effects"
+	"github.com/rs/zerolog/log"
+)
+
+func (service *Impl) equipmentSearchRequest(ctx amqp.Context, message *amqp.RabbitMQMessage) {
+	request := message.EncyclopediaEquipmentSearchRequest
+	lg := mappers.MapLanguage(message.Language)
+	if !isValidEquipmentSearchRequest(request) {
+		service.replyWithFailedAnswer(ctx, amqp.RabbitMQMessage_ENCYCLOPEDIA_EQUIPMENT_SEARCH_ANSWER,
+			message.Language)
+		return
+	}
+
+	log.Info().Str(constants.LogCorrelationID, ctx.CorrelationID).
+		Str(constants.LogQueryType, request.GetEquipmentType().String()).
+		Str(constants.LogQueryID, request.GetEffectId()).
+		Msgf("Encyclopedia Equipment Search Request received")
+
+	filter, err := mappers.MapEquipmentFilter(request)
+	if err != nil {
+		log.Error().Err(err).
+			Str(constants.LogCorrelationID, ctx.CorrelationID).
+			Str(constants.LogQueryID, request.GetEffectId()).
+			Msgf("Error while converting effect ID, returning failed request")
+		service.replyWithFailedAnswer(ctx, amqp.RabbitMQMessage_ENCYCLOPEDIA_EQUIPMENT_SEARCH_ANSWER,
+			message.Language)
+		return
+	}
+
+	trackedCtx := sources.WithSourceTracking(sources.WithGame(ctx, message.Game))
+	search, err := service.SearchEquipments(trackedCtx, filter, lg)
+	if err != nil {
+		log.Error().Err(err).
+			Str(constants.LogCorrelationID, ctx.CorrelationID).
+			Str(constants.LogQueryType, request.GetEquipmentType().String()).
+			Msgf("Error while searching equipments, returning failed request")
+		service.replyWithFailedAnswer(ctx, amqp.RabbitMQMessage_ENCYCLOPEDIA_EQUIPMENT_SEARCH_ANSWER,
+			message.Language)
+		return
+	}
+
+	response := mappers.MapEquipmentSearch(search, sources.GetServingSource(trackedCtx), message.Language)
+	service.replyWithSuceededAnswer(ctx, response)
+}
+
+func (service *Impl) SearchEquipments(ctx context.Context, filter constants.EquipmentFilter,
+	lg string) (*constants.EquipmentSearch, error) {
+	items, err := service.sourceService.FilterEquipments(ctx, filter.EquipmentType, filter.MinLevel,
+		filter.MaxLevel, lg)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]constants.EquipmentSearchResult, 0)
+	for _, item := range items {
+		var effect *constants.Characteristic
+		if filter.EffectID != 0 {
+			effect = getCharacteristic(effects.Sum(item.GetEffects()), filter.EffectID)
+			if !matchesEffectValue(effect, filter) {
+				continue
+			}
+		}
+
+		result := mappers.MapEquipmentSearchResult(item, effect, service.equipmentService)
+		if filter.EquipmentType != amqp.EquipmentType_NONE && result.Type != filter.EquipmentType {
+			continue
+		}
+
+		results = append(results, result)
+	}
+
+	sortEquipmentSearchResults(results, filter.Sort, filter.Descending)
+	offset := min(max(filter.Offset, 0), int64(len(results)))
+	end := int64(len(results))
+	if filter.Size > 0 {
+		end = min(offset+filter.Size, end)
+	}
+
+	return &constants.EquipmentSearch{
+		Results: results[offset:end],
+		Offset:  offset,
+		Total:   int64(len(results)),
+	}, nil
+}
+
+func getCharacteristic(characteristics []constants.Characteristic, effectID int32) *constants.Characteristic {
+	for _, characteristic := range characteristics {
+		if characteristic.EffectID == effectID {
+			return &characteristic
+		}
+	}
+
+	return nil
+}
+
+func matchesEffectValue(effect *constants.Characteristic, filter constants.EquipmentFilter) bool {
+	if effect == nil {
+		return false
+	}
+
+	return (filter.MinValue == nil || effect.Max >= *filter.MinValue) &&
+		(filter.MaxValue == nil || effect.Min <= *filter.MaxValue)
+}
+
+// sortEquipmentSearchResults sorts results by the given order, then by ID to keep pages stable.
+func sortEquipmentSearchResults(results []constants.EquipmentSearchResult, order constants.EquipmentSort,
+	descending bool) {
+	compare := func(i, j int) int {
+		switch order {
+		case constants.EquipmentSortName:
+			return strings.Compare(results[i].Name, results[j].Name)
+		case constants.EquipmentSortEffectValue:
+			return cmp.Compare(getEffectValue(results[i]), getEffectValue(results[j]))
+		case constants.EquipmentSortLevel:
+			return cmp.Compare(results[i].Level, results[j].Level)
+		default:
+			return 0
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		comparison := compare(i, j)
+		if descending {
+			comparison = -comparison
+		}
+		if comparison != 0 {
+			return comparison < 0
+		}
+
+		return results[i].ID < results[j].ID
+	})
+}
+
+func getEffectValue(result constants.EquipmentSearchResult) int64 {
+	if result.Effect == nil {
+		return 0
+	}
+
+	return result.Effect.Max
+}
+
+// isValidEquipmentSearchRequest requires an effect, an equipment type or a level bound,
+// so that a search cannot go through the whole equipment catalog.
+func isValidEquipmentSearchRequest(request *amqp.EncyclopediaEquipmentSearchRequest) bool {
+	return request != nil && (request.GetEffectId() != "" ||
+		request.GetEquipmentType() != amqp.EquipmentType_NONE ||
+		request.GetMinLevel() > 0 || request.GetMaxLevel() > 0)
+}
@@ -0,0 +1,171 @@
+package encyclopedias
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/dofusdude/dodugo"
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/entities"
+	"github.com/kaellybot/kaelly-encyclopedia/services/equipments"
+	"github.com/kaellybot/kaelly-encyclopedia/services/sources"
+)
+
+const (
+	testRingType     = 9
+	testHatType      = 16
+	testStrengthType = 118
+)
+
+type fakeSourceService struct {
+	sources.Service
+	equipments    []dodugo.ListItem
+	equipmentType amqp.EquipmentType
+}
+
+func (service *fakeSourceService) FilterEquipments(_ context.Context, equipmentType amqp.EquipmentType,
+	_, _ int64, _ string) ([]dodugo.ListItem, error) {
+	service.equipmentType = equipmentType
+	return service.equipments, nil
+}
+
+type fakeEquipmentService struct {
+	equipments.Service
+}
+
+func (service *fakeEquipmentService) GetTypeByDofusDude(id int32) (entities.EquipmentType, bool) {
+	equipmentTypes := map[int32]amqp.EquipmentType{
+		testRingType: amqp.EquipmentType_RING,
+		testHatType:  amqp.EquipmentType_HAT,
+	}
+
+	equipmentType, found := equipmentTypes[id]
+	return entities.EquipmentType{EquipmentID: equipmentType}, found
+}
+
+func newTestListItem(itemID, itemType, strength int32) dodugo.ListItem {
+	return dodugo.ListItem{
+		AnkamaId: dodugo.PtrInt32(itemID),
+		Name:     dodugo.PtrString("item"),
+		Type:     &dodugo.TranslatedId{Id: dodugo.PtrInt32(itemType)},
+		Level:    dodugo.PtrInt32(itemID),
+		ImageUrls: &dodugo.Images{
+			Icon: dodugo.PtrString("icon"),
+			Sd:   *dodugo.NewNullableString(dodugo.PtrString("sd")),
+			Hq:   *dodugo.NewNullableString(dodugo.PtrString("hq")),
+		},
+		Effects: []dodugo.Effect{{
+			IntMinimum: dodugo.PtrInt32(strength),
+			Type:       &dodugo.EffectType{Id: dodugo.PtrInt32(testStrengthType)},
+		}},
+	}
+}
+
+func TestEquipmentSearchRequest(t *testing.T) {
+	sourceService := fakeSourceService{
+		equipments: []dodugo.ListItem{
+			newTestListItem(10, testRingType, 20),
+			newTestListItem(20, testHatType, 60),
+			newTestListItem(30, testRingType, 40),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		request  *amqp.EncyclopediaEquipmentSearchRequest
+		status   amqp.RabbitMQMessage_Status
+		expected []string
+		total    int64
+	}{
+		{
+			name: "by type, sorted by level",
+			request: &amqp.EncyclopediaEquipmentSearchRequest{
+				EquipmentType: amqp.EquipmentType_RING,
+			},
+			status:   amqp.RabbitMQMessage_SUCCESS,
+			expected: []string{"10", "30"},
+			total:    2,
+		},
+		{
+			name: "by type, with an offset",
+			request: &amqp.EncyclopediaEquipmentSearchRequest{
+				EquipmentType: amqp.EquipmentType_RING,
+				Offset:        1,
+				Size:          1,
+			},
+			status:   amqp.RabbitMQMessage_SUCCESS,
+			expected: []string{"30"},
+			total:    2,
+		},
+		{
+			name: "by effect value, sorted by effect and paginated",
+			request: &amqp.EncyclopediaEquipmentSearchRequest{
+				EffectId:   "118",
+				MinValue:   dodugo.PtrInt64(30),
+				Sort:       amqp.EncyclopediaEquipmentSearchRequest_EFFECT_VALUE,
+				Descending: true,
+				Size:       1,
+			},
+			status:   amqp.RabbitMQMessage_SUCCESS,
+			expected: []string{"20"},
+			total:    2,
+		},
+		{
+			name:    "invalid effect ID",
+			request: &amqp.EncyclopediaEquipmentSearchRequest{EffectId: "strength"},
+			status:  amqp.RabbitMQMessage_FAILED,
+		},
+		{
+			name:    "no effect, type nor level",
+			request: &amqp.EncyclopediaEquipmentSearchRequest{Size: 1},
+			status:  amqp.RabbitMQMessage_FAILED,
+		},
+		{
+			name:   "missing request",
+			status: amqp.RabbitMQMessage_FAILED,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			broker := fakeBroker{}
+			service := Impl{broker: &broker, sourceService: &sourceService,
+				equipmentService: &fakeEquipmentService{}}
+
+			service.consume(amqp.Context{Context: context.Background()}, &amqp.RabbitMQMessage{
+				Type:                               amqp.RabbitMQMessage_ENCYCLOPEDIA_EQUIPMENT_SEARCH_REQUEST,
+				Language:                           amqp.Language_FR,
+				EncyclopediaEquipmentSearchRequest: test.request,
+			})
+
+			if len(broker.replies) != 1 {
+				t.Fatalf("expected a single reply, got %v", len(broker.replies))
+			}
+			reply := broker.replies[0]
+			if reply.Type != amqp.RabbitMQMessage_ENCYCLOPEDIA_EQUIPMENT_SEARCH_ANSWER || reply.Status != test.status {
+				t.Fatalf("expected a %v equipment search answer, got %v %v", test.status, reply.Type, reply.Status)
+			}
+			if test.status != amqp.RabbitMQMessage_SUCCESS {
+				return
+			}
+
+			if sourceService.equipmentType != test.request.EquipmentType {
+				t.Errorf("expected type %v to be filtered upstream, got %v",
+					test.request.EquipmentType, sourceService.equipmentType)
+			}
+
+			answer := reply.EncyclopediaEquipmentSearchAnswer
+			ids := make([]string, 0, len(answer.Equipments))
+			for _, equipment := range answer.Equipments {
+				ids = append(ids, equipment.Id)
+				if equipment.Icon != "sd" {
+					t.Errorf("expected SD icon, got %v", equipment.Icon)
+				}
+			}
+			if !slices.Equal(ids, test.expected) || answer.Total != test.total {
+				t.Errorf("expected %v out of %v, got %v out of %v", test.expected, test.total, ids, answer.Total)
+			}
+		})
+	}
+}
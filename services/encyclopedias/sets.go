@@ -4,8 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 
-	"github.com/dofusdude/dodugo"
 	amqp "github.com/kaellybot/kaelly-amqp"
 	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
 	"github.com/kaellybot/kaelly-encyclopedia/models/mappers"
@@ -13,21 +13,84 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+func (service *Impl) setBonusRequest(ctx amqp.Context, message *amqp.RabbitMQMessage) {
+	request := message.EncyclopediaSetBonusRequest
+	lg := mappers.MapLanguage(message.Language)
+	if !isValidSetBonusRequest(request) {
+		service.replyWithFailedAnswer(ctx, amqp.RabbitMQMessage_ENCYCLOPEDIA_SET_BONUS_ANSWER,
+			message.Language)
+		return
+	}
+
+	log.Info().Str(constants.LogCorrelationID, ctx.CorrelationID).
+		Str(constants.LogQueryID, request.Query).
+		Msgf("Encyclopedia Set Bonus Request received")
+
+	trackedCtx := sources.WithSourceTracking(sources.WithGame(ctx, message.Game))
+	setBonus, err := service.getSetBonus(trackedCtx, request, ctx.CorrelationID, lg)
+	if err != nil && !errors.Is(err, sources.ErrNotFound) {
+		log.Error().Err(err).
+			Str(constants.LogCorrelationID, ctx.CorrelationID).
+			Str(constants.LogQueryID, request.Query).
+			Msgf("Error while computing set bonus, returning failed request")
+		service.replyWithFailedAnswer(ctx, amqp.RabbitMQMessage_ENCYCLOPEDIA_SET_BONUS_ANSWER,
+			message.Language)
+		return
+	}
+
+	response := mappers.MapSetBonus(request.Query, setBonus, sources.GetServingSource(trackedCtx),
+		message.Language)
+	service.replyWithSuceededAnswer(ctx, response)
+}
+
+func (service *Impl) getSetBonus(ctx context.Context, request *amqp.EncyclopediaSetBonusRequest,
+	correlationID, lg string) (*constants.SetBonus, error) {
+	equippedIDs := make([]int64, 0, len(request.GetEquippedIds()))
+	for _, equippedID := range request.GetEquippedIds() {
+		itemID, err := strconv.ParseInt(equippedID, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+
+		equippedIDs = append(equippedIDs, itemID)
+	}
+
+	var setID int64
+	if request.GetIsID() {
+		var err error
+		setID, err = strconv.ParseInt(request.Query, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		set, err := service.sourceService.GetSetByQuery(ctx, request.Query, lg)
+		if err != nil {
+			return nil, err
+		}
+
+		setID = int64(set.GetAnkamaId())
+	}
+
+	return service.setService.GetSetBonus(ctx, setID, equippedIDs, correlationID, lg)
+}
+
 func (service *Impl) getSetByID(ctx context.Context, id int64, correlationID,
 	lg string) (*amqp.EncyclopediaItemAnswer, error) {
 	query := fmt.Sprintf("%v", id)
 	set, err := service.sourceService.GetSetByID(ctx, id, lg)
 	if err != nil {
 		if errors.Is(err, sources.ErrNotFound) {
-			return mappers.MapSet(query, nil, nil, "", service.equipmentService), nil
+			return mappers.MapSet(query, nil, nil, "", sources.GetServingSource(ctx),
+				service.equipmentService), nil
 		}
 
 		return nil, err
 	}
 
-	items := service.getSetEquipments(ctx, set, correlationID, lg)
-	icon := service.getSetIcon(int64(set.GetAnkamaId()))
-	return mappers.MapSet(query, set, items, icon, service.equipmentService), nil
+	items := service.setService.GetSetEquipments(ctx, set, correlationID, lg)
+	icon := service.getSetIcon(ctx, int64(set.GetAnkamaId()))
+	return mappers.MapSet(query, set, items, icon, sources.GetServingSource(ctx),
+		service.equipmentService), nil
 }
 
 func (service *Impl) getSetByQuery(ctx context.Context, query, correlationID,
@@ -35,47 +98,28 @@ func (service *Impl) getSetByQuery(ctx context.Context, query, correlationID,
 	set, err := service.sourceService.GetSetByQuery(ctx, query, lg)
 	if err != nil {
 		if errors.Is(err, sources.ErrNotFound) {
-			return mappers.MapSet(query, nil, nil, "", service.equipmentService), nil
+			return mappers.MapSet(query, nil, nil, "", sources.GetServingSource(ctx),
+				service.equipmentService), nil
 		}
 
 		return nil, err
 	}
 
-	items := service.getSetEquipments(ctx, set, correlationID, lg)
-	icon := service.getSetIcon(int64(set.GetAnkamaId()))
-	return mappers.MapSet(query, set, items, icon, service.equipmentService), nil
-}
-
-func (service *Impl) getSetEquipments(ctx context.Context, set *dodugo.EquipmentSet, correlationID,
-	lg string) map[int32]*dodugo.Weapon {
-	var getItemByID func(ctx context.Context, equipmentID int64, lg string) (*dodugo.Weapon, error)
-	if set.GetContainsCosmeticsOnly() {
-		getItemByID = service.sourceService.GetCosmeticByID
-	} else {
-		getItemByID = service.sourceService.GetEquipmentByID
-	}
-
-	items := make(map[int32]*dodugo.Weapon)
-	for _, itemID := range set.GetEquipmentIds() {
-		item, errItem := getItemByID(ctx, int64(itemID), lg)
-		if errItem != nil {
-			log.Error().Err(errItem).
-				Str(constants.LogCorrelationID, correlationID).
-				Str(constants.LogAnkamaID, fmt.Sprintf("%v", itemID)).
-				Msgf("Error while retrieving item with DofusDude, continuing without it")
-		} else {
-			items[itemID] = item
-		}
-	}
-
-	return items
+	items := service.setService.GetSetEquipments(ctx, set, correlationID, lg)
+	icon := service.getSetIcon(ctx, int64(set.GetAnkamaId()))
+	return mappers.MapSet(query, set, items, icon, sources.GetServingSource(ctx),
+		service.equipmentService), nil
 }
 
-func (service *Impl) getSetIcon(setID int64) string {
-	setDB, found := service.setService.GetSetByDofusDude(setID)
+func (service *Impl) getSetIcon(ctx context.Context, setID int64) string {
+	setDB, found := service.setService.GetSetByDofusDude(sources.GetGame(ctx), setID)
 	if found {
 		return setDB.Icon
 	}
 
 	return ""
 }
+
+func isValidSetBonusRequest(request *amqp.EncyclopediaSetBonusRequest) bool {
+	return request != nil
+}
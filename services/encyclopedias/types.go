@@ -3,10 +3,13 @@ package encyclopedias
 import (
 	"context"
 	"errors"
+	"time"
 
 	amqp "github.com/kaellybot/kaelly-amqp"
 	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
 	"github.com/kaellybot/kaelly-encyclopedia/services/almanaxes"
+	"github.com/kaellybot/kaelly-encyclopedia/services/builds"
+	"github.com/kaellybot/kaelly-encyclopedia/services/crafts"
 	"github.com/kaellybot/kaelly-encyclopedia/services/equipments"
 	"github.com/kaellybot/kaelly-encyclopedia/services/sets"
 	"github.com/kaellybot/kaelly-encyclopedia/services/sources"
@@ -15,21 +18,23 @@ import (
 const (
 	requestQueueName   = "encyclopedias-requests"
 	requestsRoutingkey = "requests.encyclopedias"
+
+	defaultCraftQuantity = 1
 )
 
 var (
 	errBadRequestMessage = errors.New("message request could not be satisfied")
 	errUnknownQuery      = errors.New("cannot determine query type")
+
+	ErrComparisonSize = errors.New("wrong number of equipments to compare")
 )
 
 type getListFunc func(ctx context.Context, query, correlationID,
-	lg string) (*amqp.EncyclopediaListAnswer, error)
+	lg string) ([]constants.ListEntry, error)
 type getItemByIDFunc func(ctx context.Context, ID int64, correlationID,
 	lg string) (*amqp.EncyclopediaItemAnswer, error)
 type getItemByQueryFunc func(ctx context.Context, query, correlationID,
 	lg string) (*amqp.EncyclopediaItemAnswer, error)
-type getIngredientByIDFunc func(ctx context.Context, ID int64, correlationID,
-	lg string) (*constants.Ingredient, error)
 
 type getItemFuncs struct {
 	GetItemByID    getItemByIDFunc
@@ -38,15 +43,39 @@ type getItemFuncs struct {
 
 type Service interface {
 	Consume() error
+	// CompareEquipments compares 2 to 4 equipments retrieved by ID or query, side by side.
+	// Conditions are evaluated against the character profile if given.
+	CompareEquipments(ctx context.Context, requests []*amqp.EncyclopediaItemRequest,
+		profile *constants.CharacterProfile, correlationID, lg string) (*constants.EquipmentComparison, error)
+	// GetAlmanaxCalendar retrieves the almanax of every day from start to end, both included.
+	// Past dates and dates of the next year are supported, within the DofusDude almanax size limit.
+	GetAlmanaxCalendar(ctx context.Context, start, end time.Time, lg string) ([]*amqp.Almanax, error)
+	// GetAlmanaxesByTribute retrieves the next almanax days requiring the item, by ID or query, as tribute.
+	// Days are paginated with offset and size like the almanax effect search.
+	GetAlmanaxesByTribute(ctx context.Context, request *amqp.EncyclopediaItemRequest, offset, size int64,
+		correlationID, lg string) (*constants.TributeAlmanaxes, error)
+	// GetList retrieves the entries matching the query, filtered, sorted and paginated.
+	GetList(ctx context.Context, listType amqp.EncyclopediaListRequest_Type, query string,
+		filter constants.ListFilter, correlationID, lg string) (*constants.List, error)
+	// SearchEquipments retrieves equipments by type, level range and effect value, sorted and paginated.
+	SearchEquipments(ctx context.Context, filter constants.EquipmentFilter,
+		lg string) (*constants.EquipmentSearch, error)
+	// GetTributeShoppingList aggregates the tributes needed from start to end, both included, one per item.
+	GetTributeShoppingList(ctx context.Context, start, end time.Time, tributeSort constants.TributeSort,
+		lg string) (*constants.TributeShoppingList, error)
+	// SimulateBuild computes the characteristics of a loadout and checks its slot rules and conditions.
+	SimulateBuild(ctx context.Context, request constants.BuildRequest, correlationID,
+		lg string) (*constants.Build, error)
 }
 
 type Impl struct {
-	sourceService        sources.Service
-	almanaxService       almanaxes.Service
-	equipmentService     equipments.Service
-	setService           sets.Service
-	broker               amqp.MessageBroker
-	getListByFunc        map[amqp.EncyclopediaListRequest_Type]getListFunc
-	getItemByFuncs       map[amqp.ItemType]getItemFuncs
-	getIngredientByFuncs map[amqp.ItemType]getIngredientByIDFunc
+	sourceService    sources.Service
+	buildService     builds.Service
+	craftService     crafts.Service
+	almanaxService   almanaxes.Service
+	equipmentService equipments.Service
+	setService       sets.Service
+	broker           amqp.MessageBroker
+	getListByFunc    map[amqp.EncyclopediaListRequest_Type]getListFunc
+	getItemByFuncs   map[amqp.ItemType]getItemFuncs
 }
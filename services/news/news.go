@@ -3,6 +3,7 @@ package news
 import (
 	"github.com/dofusdude/dodugo"
 	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
 	"github.com/kaellybot/kaelly-encyclopedia/models/mappers"
 	"github.com/kaellybot/kaelly-encyclopedia/services/sources"
 	"github.com/rs/zerolog/log"
@@ -17,27 +18,28 @@ func New(broker amqp.MessageBroker, sourceService sources.Service) *Impl {
 	return &service
 }
 
-func (service *Impl) PublishAlmanaxNews(almanaxes []*amqp.NewsAlmanaxMessage_I18NAlmanax) {
+func (service *Impl) PublishAlmanaxNews(almanaxes []*amqp.NewsAlmanaxMessage_I18NAlmanax,
+	source constants.Source) {
 	log.Info().Msgf("Publishing almanax news...")
-	err := service.broker.Emit(mappers.MapAlmanaxNews(almanaxes),
+	err := service.broker.Emit(mappers.MapAlmanaxNews(almanaxes, source),
 		amqp.ExchangeNews, newsAlmanaxRoutingKey, amqp.GenerateUUID())
 	if err != nil {
 		log.Error().Err(err).Msgf("Almanax news failed to be published")
 	}
 }
 
-func (service *Impl) PublishGameNews(gameVersion string) {
+func (service *Impl) PublishGameNews(game amqp.Game, gameVersion string) {
 	log.Info().Msgf("Publishing game version news...")
-	err := service.broker.Emit(mappers.MapGameNews(gameVersion),
+	err := service.broker.Emit(mappers.MapGameNews(game, gameVersion),
 		amqp.ExchangeNews, newsGameRoutingKey, amqp.GenerateUUID())
 	if err != nil {
 		log.Error().Err(err).Msgf("Game news failed to be published")
 	}
 }
 
-func (service *Impl) PublishSetNews(sets []dodugo.ListEquipmentSet) {
+func (service *Impl) PublishSetNews(game amqp.Game, sets []dodugo.ListEquipmentSet) {
 	log.Info().Msgf("Publishing missing sets news...")
-	err := service.broker.Emit(mappers.MapSetNews(sets),
+	err := service.broker.Emit(mappers.MapSetNews(game, sets),
 		amqp.ExchangeNews, newsSetRoutingKey, amqp.GenerateUUID())
 	if err != nil {
 		log.Error().Err(err).Msgf("Set news failed to be published")
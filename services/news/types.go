@@ -3,6 +3,7 @@ package news
 import (
 	"github.com/dofusdude/dodugo"
 	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
 )
 
 const (
@@ -12,9 +13,9 @@ const (
 )
 
 type Service interface {
-	PublishAlmanaxNews(almanaxes []*amqp.NewsAlmanaxMessage_I18NAlmanax)
-	PublishGameNews(gameVersion string)
-	PublishSetNews(missingSets []dodugo.ListEquipmentSet)
+	PublishAlmanaxNews(almanaxes []*amqp.NewsAlmanaxMessage_I18NAlmanax, source constants.Source)
+	PublishGameNews(game amqp.Game, gameVersion string)
+	PublishSetNews(game amqp.Game, missingSets []dodugo.ListEquipmentSet)
 }
 
 type Impl struct {
@@ -0,0 +1,142 @@
+package sets
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/dofusdude/dodugo"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+	"github.com/kaellybot/kaelly-encyclopedia/utils/effects"
+	"github.com/rs/zerolog/log"
+)
+
+func (service *Impl) GetSetEquipments(ctx context.Context, set *dodugo.EquipmentSet, correlationID,
+	lg string) map[int32]*dodugo.Weapon {
+	getItemByID := service.getSetItemFunc(set)
+	items := make(map[int32]*dodugo.Weapon)
+	for _, itemID := range set.GetEquipmentIds() {
+		item, errItem := getItemByID(ctx, int64(itemID), lg)
+		if errItem != nil {
+			log.Error().Err(errItem).
+				Str(constants.LogCorrelationID, correlationID).
+				Str(constants.LogAnkamaID, fmt.Sprintf("%v", itemID)).
+				Msgf("Error while retrieving item with DofusDude, continuing without it")
+		} else {
+			items[itemID] = item
+		}
+	}
+
+	return items
+}
+
+func (service *Impl) GetSetBonus(ctx context.Context, setID int64, equippedIDs []int64,
+	correlationID, lg string) (*constants.SetBonus, error) {
+	set, err := service.sourceService.GetSetByID(ctx, setID, lg)
+	if err != nil {
+		return nil, err
+	}
+
+	setItemIDs := make(map[int64]struct{})
+	for _, itemID := range set.GetEquipmentIds() {
+		setItemIDs[int64(itemID)] = struct{}{}
+	}
+
+	getItemByID := service.getSetItemFunc(set)
+	equipped := make(map[int64]struct{})
+	equippedIDsInSet := make([]int64, 0)
+	itemEffects := make([][]dodugo.Effect, 0)
+	for _, itemID := range equippedIDs {
+		if _, found := equipped[itemID]; found {
+			continue
+		}
+
+		if _, found := setItemIDs[itemID]; !found {
+			log.Warn().
+				Str(constants.LogCorrelationID, correlationID).
+				Str(constants.LogAnkamaID, fmt.Sprintf("%v", itemID)).
+				Msgf("Equipped item does not belong to the set, ignoring it")
+			continue
+		}
+
+		item, errItem := getItemByID(ctx, itemID, lg)
+		if errItem != nil {
+			log.Error().Err(errItem).
+				Str(constants.LogCorrelationID, correlationID).
+				Str(constants.LogAnkamaID, fmt.Sprintf("%v", itemID)).
+				Msgf("Error while retrieving item with DofusDude, continuing without it")
+			continue
+		}
+
+		equipped[itemID] = struct{}{}
+		equippedIDsInSet = append(equippedIDsInSet, itemID)
+		itemEffects = append(itemEffects, item.GetEffects())
+	}
+
+	tiers := getBonusTiers(set, correlationID)
+	itemNumber, nextItemNumber := getActiveTiers(tiers, int64(len(equippedIDsInSet)))
+	bonus := effects.Sum(tiers[itemNumber])
+	nextGains := make([]constants.Characteristic, 0)
+	if nextItemNumber > 0 {
+		nextGains = effects.Diff(bonus, effects.Sum(tiers[nextItemNumber]))
+	}
+
+	return &constants.SetBonus{
+		SetID:           int64(set.GetAnkamaId()),
+		SetName:         set.GetName(),
+		EquippedIDs:     equippedIDsInSet,
+		ItemNumber:      itemNumber,
+		Bonus:           bonus,
+		NextItemNumber:  nextItemNumber,
+		NextGains:       nextGains,
+		Characteristics: effects.Sum(append(itemEffects, tiers[itemNumber])...),
+	}, nil
+}
+
+// getSetItemFunc returns the function retrieving set items, cosmetic sets holding cosmetics only.
+func (service *Impl) getSetItemFunc(set *dodugo.EquipmentSet,
+) func(ctx context.Context, itemID int64, lg string) (*dodugo.Weapon, error) {
+	if set.GetContainsCosmeticsOnly() {
+		return service.sourceService.GetCosmeticByID
+	}
+
+	return service.sourceService.GetEquipmentByID
+}
+
+// getBonusTiers returns the set bonuses by number of equipped items; combinations without effects are left out.
+func getBonusTiers(set *dodugo.EquipmentSet, correlationID string) map[int64][]dodugo.Effect {
+	tiers := make(map[int64][]dodugo.Effect)
+	for itemNumberStr, bonus := range set.GetEffects() {
+		if len(bonus) == 0 {
+			continue
+		}
+
+		itemNumber, err := strconv.ParseInt(itemNumberStr, 10, 64)
+		if err != nil {
+			log.Error().Err(err).
+				Str(constants.LogCorrelationID, correlationID).
+				Msgf("Cannot convert itemNumber '%v' as int64, ignoring this effect combination", itemNumberStr)
+			continue
+		}
+
+		tiers[itemNumber] = bonus
+	}
+
+	return tiers
+}
+
+// getActiveTiers returns the highest tier reached with the number of equipped items and the following one.
+// 0 is returned when there is no such tier.
+func getActiveTiers(tiers map[int64][]dodugo.Effect, equippedCount int64) (int64, int64) {
+	var active, next int64
+	for itemNumber := range tiers {
+		if itemNumber <= equippedCount && itemNumber > active {
+			active = itemNumber
+		}
+		if itemNumber > equippedCount && (next == 0 || itemNumber < next) {
+			next = itemNumber
+		}
+	}
+
+	return active, next
+}
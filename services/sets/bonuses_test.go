@@ -0,0 +1,93 @@
+package sets
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/dofusdude/dodugo"
+	"github.com/kaellybot/kaelly-encyclopedia/services/sources"
+)
+
+type fakeSourceService struct {
+	sources.Service
+	set     *dodugo.EquipmentSet
+	fetched []int64
+}
+
+func (service *fakeSourceService) GetSetByID(_ context.Context, _ int64, _ string) (*dodugo.EquipmentSet, error) {
+	return service.set, nil
+}
+
+func (service *fakeSourceService) GetEquipmentByID(_ context.Context, equipmentID int64,
+	_ string) (*dodugo.Weapon, error) {
+	service.fetched = append(service.fetched, equipmentID)
+	return &dodugo.Weapon{}, nil
+}
+
+func TestGetBonusTiers(t *testing.T) {
+	effect := dodugo.Effect{IntMinimum: dodugo.PtrInt32(10)}
+	set := dodugo.EquipmentSet{
+		Effects: &map[string][]dodugo.Effect{
+			"1": {},
+			"2": {effect},
+			"4": {effect, effect},
+			"x": {effect},
+		},
+	}
+
+	tiers := getBonusTiers(&set, "")
+	if len(tiers) != 2 || len(tiers[2]) != 1 || len(tiers[4]) != 2 {
+		t.Errorf("expected tiers 2 and 4 only, got %v", tiers)
+	}
+}
+
+func TestGetActiveTiers(t *testing.T) {
+	tiers := map[int64][]dodugo.Effect{2: {}, 4: {}, 6: {}}
+	tests := []struct {
+		name           string
+		equippedCount  int64
+		expectedActive int64
+		expectedNext   int64
+	}{
+		{name: "no item equipped", equippedCount: 0, expectedActive: 0, expectedNext: 2},
+		{name: "below the first tier", equippedCount: 1, expectedActive: 0, expectedNext: 2},
+		{name: "exactly on a tier", equippedCount: 4, expectedActive: 4, expectedNext: 6},
+		{name: "between tiers", equippedCount: 5, expectedActive: 4, expectedNext: 6},
+		{name: "last tier reached", equippedCount: 8, expectedActive: 6, expectedNext: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			active, next := getActiveTiers(tiers, test.equippedCount)
+			if active != test.expectedActive || next != test.expectedNext {
+				t.Errorf("expected tiers %v and %v, got %v and %v",
+					test.expectedActive, test.expectedNext, active, next)
+			}
+		})
+	}
+}
+
+func TestGetSetBonus(t *testing.T) {
+	effect := dodugo.Effect{IntMinimum: dodugo.PtrInt32(10)}
+	sourceService := fakeSourceService{
+		set: &dodugo.EquipmentSet{
+			AnkamaId:     dodugo.PtrInt32(1),
+			EquipmentIds: []int32{10, 20, 30},
+			Effects:      &map[string][]dodugo.Effect{"2": {effect}},
+		},
+	}
+	service := Impl{sourceService: &sourceService}
+
+	bonus, err := service.GetSetBonus(context.Background(), 1, []int64{20, 40, 20, 10}, "", "fr")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !slices.Equal(sourceService.fetched, []int64{20, 10}) {
+		t.Errorf("expected equipped set items only to be retrieved, got %v", sourceService.fetched)
+	}
+	if !slices.Equal(bonus.EquippedIDs, []int64{20, 10}) || bonus.ItemNumber != 2 {
+		t.Errorf("expected items 20 and 10 to reach tier 2, got %v on tier %v", bonus.EquippedIDs, bonus.ItemNumber)
+	}
+}
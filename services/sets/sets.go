@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/dofusdude/dodugo"
+	amqp "github.com/kaellybot/kaelly-amqp"
 	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
 	"github.com/kaellybot/kaelly-encyclopedia/models/entities"
 	repository "github.com/kaellybot/kaelly-encyclopedia/repositories/sets"
@@ -19,7 +20,7 @@ func New(repository repository.Repository, newsService news.Service,
 		newsService:      newsService,
 		sourceService:    sourceService,
 		equipmentService: equipmentService,
-		sets:             make(map[int64]entities.Set),
+		sets:             make(map[amqp.Game]map[int64]entities.Set),
 		repository:       repository,
 	}
 
@@ -32,8 +33,8 @@ func New(repository repository.Repository, newsService news.Service,
 	return &service, nil
 }
 
-func (service *Impl) GetSetByDofusDude(id int64) (entities.Set, bool) {
-	item, found := service.sets[id]
+func (service *Impl) GetSetByDofusDude(game amqp.Game, id int64) (entities.Set, bool) {
+	item, found := service.sets[game][id]
 	return item, found
 }
 
@@ -48,15 +49,20 @@ func (service *Impl) loadSetFromDB() error {
 		Msgf("Sets loaded")
 
 	for _, set := range sets {
-		service.sets[int64(set.DofusDudeID)] = set
+		gameSets, found := service.sets[set.Game]
+		if !found {
+			gameSets = make(map[int64]entities.Set)
+			service.sets[set.Game] = gameSets
+		}
+		gameSets[int64(set.DofusDudeID)] = set
 	}
 
 	return nil
 }
 
-func (service *Impl) checkMissingSets(_ string) {
-	log.Info().Msgf("Checking missing set icons...")
-	ctx := context.Background()
+func (service *Impl) checkMissingSets(game amqp.Game, _ string) {
+	log.Info().Msgf("Checking missing %v set icons...", game)
+	ctx := sources.WithGame(context.Background(), game)
 
 	sets, errGet := service.sourceService.GetSets(ctx)
 	if errGet != nil {
@@ -66,7 +72,7 @@ func (service *Impl) checkMissingSets(_ string) {
 
 	missingSets := make([]dodugo.ListEquipmentSet, 0)
 	for _, set := range sets {
-		if _, found := service.sets[int64(set.GetAnkamaId())]; !found {
+		if _, found := service.sets[game][int64(set.GetAnkamaId())]; !found {
 			missingSets = append(missingSets, set)
 		}
 	}
@@ -77,5 +83,5 @@ func (service *Impl) checkMissingSets(_ string) {
 	}
 
 	log.Info().Int(constants.LogEntityCount, len(missingSets)).Msgf("Set icons to build")
-	service.newsService.PublishSetNews(missingSets)
+	service.newsService.PublishSetNews(game, missingSets)
 }
@@ -1,6 +1,11 @@
 package sets
 
 import (
+	"context"
+
+	"github.com/dofusdude/dodugo"
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
 	"github.com/kaellybot/kaelly-encyclopedia/models/entities"
 	repository "github.com/kaellybot/kaelly-encyclopedia/repositories/sets"
 	"github.com/kaellybot/kaelly-encyclopedia/services/equipments"
@@ -9,11 +14,18 @@ import (
 )
 
 type Service interface {
-	GetSetByDofusDude(ID int64) (entities.Set, bool)
+	GetSetByDofusDude(game amqp.Game, ID int64) (entities.Set, bool)
+	// GetSetEquipments retrieves the set items; items which cannot be retrieved are left out.
+	GetSetEquipments(ctx context.Context, set *dodugo.EquipmentSet, correlationID,
+		lg string) map[int32]*dodugo.Weapon
+	// GetSetBonus computes the bonus tiers and the characteristics of a partially equipped set.
+	// Equipped items which do not belong to the set are ignored.
+	GetSetBonus(ctx context.Context, setID int64, equippedIDs []int64, correlationID,
+		lg string) (*constants.SetBonus, error)
 }
 
 type Impl struct {
-	sets             map[int64]entities.Set
+	sets             map[amqp.Game]map[int64]entities.Set
 	newsService      news.Service
 	sourceService    sources.Service
 	equipmentService equipments.Service
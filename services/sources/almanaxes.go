@@ -0,0 +1,115 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dofusdude/dodugo"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+	"github.com/kaellybot/kaelly-encyclopedia/utils/conversions"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/errgroup"
+)
+
+func (service *Impl) SearchAlmanaxEffects(ctx context.Context, query,
+	language string) ([]dodugo.GetMetaAlmanaxBonuses200ResponseInner, error) {
+	effects, _, err := fetch(ctx, service, almanaxEffect,
+		func(source string) string { return buildListKey(almanaxEffect, query, language, source) },
+		func(ctx context.Context, provider Provider) ([]dodugo.GetMetaAlmanaxBonuses200ResponseInner, error) {
+			return provider.SearchAlmanaxEffects(ctx, query, language)
+		})
+	return effects, err
+}
+
+func (service *Impl) GetAlmanaxByDate(ctx context.Context, date time.Time, language string,
+) (*dodugo.Almanax, error) {
+	dodugoAlmanaxDate := date.Format(constants.DofusDudeAlmanaxDateFormat)
+	dodugoAlmanax, source, err := fetch(ctx, service, almanax,
+		func(source string) string { return buildItemKey(almanax, dodugoAlmanaxDate, language, source) },
+		func(ctx context.Context, provider Provider) (*dodugo.Almanax, error) {
+			return provider.GetAlmanaxByDate(ctx, dodugoAlmanaxDate, language)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	if dodugoAlmanax == nil {
+		currentYear := time.Now().Year()
+		if currentYear != date.Year() {
+			log.Warn().
+				Str(constants.LogDate, dodugoAlmanaxDate).
+				Msgf("Source returns 404 NOT_FOUND for specific date, continuing with closest date...")
+			fallbackDate := time.Date(currentYear, date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+			fallbackAlmanax, errFallback := service.GetAlmanaxByDate(ctx, fallbackDate, language)
+			if fallbackAlmanax != nil {
+				fallbackAlmanax.SetDate(dodugoAlmanaxDate)
+				key := buildItemKey(almanax, dodugoAlmanaxDate, language, source.Name)
+				service.putElementToCache(ctx, almanax, key, fallbackAlmanax)
+			}
+			return fallbackAlmanax, errFallback
+		}
+
+		log.Error().
+			Str(constants.LogDate, dodugoAlmanaxDate).
+			Msgf("Source returns 404 NOT_FOUND for a close date, continuing with nil almanax...")
+	}
+
+	return dodugoAlmanax, nil
+}
+
+func (service *Impl) GetAlmanaxByRange(ctx context.Context, daysDuration int64, language string,
+) ([]dodugo.Almanax, error) {
+	int32DaysDuration, errConv := conversions.Int64ToInt32(daysDuration)
+	if errConv != nil {
+		return nil, errConv
+	}
+
+	dodugoAlmanaxDate := time.Now().Format(constants.DofusDudeAlmanaxDateFormat)
+	dodugoAlmanax, _, err := fetch(ctx, service, almanaxRange,
+		func(source string) string {
+			return buildItemKey(almanaxRange, fmt.Sprintf("%v_%v", dodugoAlmanaxDate, daysDuration),
+				language, source)
+		},
+		func(ctx context.Context, provider Provider) ([]dodugo.Almanax, error) {
+			return provider.GetAlmanaxByRange(ctx, int32DaysDuration, language)
+		})
+	return dodugoAlmanax, err
+}
+
+func (service *Impl) GetAlmanaxBetweenDates(ctx context.Context, start, end time.Time, language string,
+) ([]dodugo.Almanax, error) {
+	startDay := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	endDay := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(endDay.Sub(startDay)/almanaxDayDuration) + 1
+	if days <= 0 || days > constants.DofusDudeAlmanaxSizeLimit {
+		return nil, ErrInvalidRange
+	}
+
+	dodugoAlmanaxes := make([]dodugo.Almanax, days)
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(almanaxFetchConcurrency)
+	for i := range days {
+		date := startDay.AddDate(0, 0, i)
+		group.Go(func() error {
+			dodugoAlmanax, err := service.GetAlmanaxByDate(groupCtx, date, language)
+			if err != nil {
+				return err
+			}
+
+			if dodugoAlmanax == nil {
+				return fmt.Errorf("%w: almanax of %v", ErrNotFound,
+					date.Format(constants.DofusDudeAlmanaxDateFormat))
+			}
+
+			dodugoAlmanaxes[i] = *dodugoAlmanax
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	return dodugoAlmanaxes, nil
+}
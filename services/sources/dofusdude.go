@@ -2,148 +2,349 @@ package sources
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand/v2"
+	"net"
 	"net/http"
+	"slices"
 	"time"
 
 	"github.com/dofusdude/dodugo"
 	amqp "github.com/kaellybot/kaelly-amqp"
 	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
-	"github.com/kaellybot/kaelly-encyclopedia/utils/conversions"
+	"github.com/kaellybot/kaelly-encyclopedia/utils/breakers"
+	"github.com/kaellybot/kaelly-encyclopedia/utils/limiters"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+	"golang.org/x/sync/errgroup"
 )
 
-func (service *Impl) GetItemType(itemType string) amqp.ItemType {
-	amqpItemType, found := service.itemTypes[itemType]
-	if !found {
-		log.Warn().
-			Str(constants.LogItemType, itemType).
-			Msgf("Cannot find dofusDude itemType match, returning amqp.ItemType_ANY_ITEM")
-		return amqp.ItemType_ANY_ITEM_TYPE
-	}
-
-	return amqpItemType
-}
-
-func (service *Impl) SearchAnyItems(ctx context.Context, query,
-	language string) ([]dodugo.GameSearch, error) {
-	ctx, cancel := context.WithTimeout(ctx, service.httpTimeout)
-	defer cancel()
-
-	var items []dodugo.GameSearch
-	key := buildListKey(item, query, language, constants.GetEncyclopediasSource().Name)
-	if !service.getElementFromCache(ctx, key, &items) {
-		resp, r, err := service.dofusDudeClient.
-			GameAPI.
-			GetGameSearch(ctx, language, constants.DofusDudeGame).
-			Query(query).
-			FilterSearchIndex(constants.GetSupportedSearchIndex()).
-			FilterTypeNameId(constants.GetSupportedTypeEnums()).
-			Limit(constants.DofusDudeLimit).Execute()
-		if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
-			return nil, err
+func newDofusDudeProvider() *dofusDudeProvider {
+	config := dodugo.NewConfiguration()
+	config.UserAgent = constants.UserAgent
+	config.HTTPClient = &http.Client{
+		Transport: limiters.NewTransport(constants.DofusDudeSourceName, http.DefaultTransport,
+			viper.GetFloat64(constants.DofusDudeRateLimit), viper.GetInt(constants.DofusDudeRateBurst),
+			viper.GetDuration(constants.DofusDudeRateMaxWait)),
+	}
+	provider := dofusDudeProvider{
+		client: dodugo.NewAPIClient(config),
+		breaker: breakers.New(constants.DofusDudeSourceName,
+			viper.GetInt(constants.CircuitBreakerThreshold),
+			viper.GetDuration(constants.CircuitBreakerCooldown)),
+		retries:    viper.GetInt(constants.DofusDudeRetries),
+		backoff:    viper.GetDuration(constants.DofusDudeRetryBackoff),
+		maxBackoff: viper.GetDuration(constants.DofusDudeRetryMaxBackoff),
+		retryCounter: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   constants.MetricNamespace,
+			Name:        "source_retries_total",
+			Help:        "Number of retried calls to an upstream source.",
+			ConstLabels: prometheus.Labels{constants.MetricLabelSource: constants.DofusDudeSourceName},
+		}),
+	}
+
+	if err := prometheus.Register(provider.retryCounter); err != nil {
+		log.Warn().Err(err).
+			Str(constants.LogSource, constants.DofusDudeSourceName).
+			Msgf("Cannot register retry metric, continuing without it...")
+	}
+
+	return &provider
+}
+
+func (provider *dofusDudeProvider) GetSource() constants.Source {
+	return constants.GetDofusDudeSource()
+}
+
+func (provider *dofusDudeProvider) IsGameSupported(game amqp.Game) bool {
+	_, found := constants.GetDofusDudeGames()[game]
+	return found
+}
+
+func (provider *dofusDudeProvider) GetStatus() string {
+	return fmt.Sprintf("circuit breaker %v", provider.breaker.GetState())
+}
+
+// SearchAnyItems searches type filtered indexes apart: DofusDude applies a type filter to every searched index,
+// which would leave consumables, quest items and resources out. Both searches run concurrently.
+func (provider *dofusDudeProvider) SearchAnyItems(ctx context.Context, query, language string,
+) ([]dodugo.GameSearch, error) {
+	typeFilteredIndexes := constants.GetTypeFilteredSearchIndex()
+	otherIndexes := make([]string, 0)
+	for _, searchIndex := range constants.GetSupportedSearchIndex() {
+		if !slices.Contains(typeFilteredIndexes, searchIndex) {
+			otherIndexes = append(otherIndexes, searchIndex)
 		}
-		defer r.Body.Close()
-		service.putElementToCache(ctx, key, resp)
-		items = resp
 	}
 
-	return items, nil
+	var typeFilteredResp, otherResp []dodugo.GameSearch
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.Go(func() error {
+		var err error
+		typeFilteredResp, err = provider.searchGame(groupCtx, query, language, typeFilteredIndexes,
+			constants.GetSupportedTypeEnums())
+		return err
+	})
+	group.Go(func() error {
+		var err error
+		otherResp, err = provider.searchGame(groupCtx, query, language, otherIndexes, nil)
+		return err
+	})
+
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	return append(typeFilteredResp, otherResp...), nil
 }
 
-//nolint:dupl // Complicated to be more DRY.
-func (service *Impl) GetConsumableByID(ctx context.Context, itemID int64, language string,
-) (*dodugo.Resource, error) {
-	ctx, cancel := context.WithTimeout(ctx, service.httpTimeout)
-	defer cancel()
+// searchGame searches the given indexes; types are not filtered if typeNameIDs is empty.
+func (provider *dofusDudeProvider) searchGame(ctx context.Context, query, language string,
+	searchIndexes, typeNameIDs []string) ([]dodugo.GameSearch, error) {
+	request := provider.client.GameAPI.
+		GetGameSearch(ctx, language, getDofusDudeGame(ctx)).
+		Query(query).
+		FilterSearchIndex(searchIndexes).
+		FieldsItem(constants.GetSearchItemFields()).
+		Limit(constants.DofusDudeLimit)
+	if len(typeNameIDs) > 0 {
+		request = request.FilterTypeNameId(typeNameIDs)
+	}
+
+	resp, r, err := execute(ctx, provider, request.Execute)
+	if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
+		return nil, err
+	}
+	defer r.Body.Close()
+	return resp, nil
+}
 
-	int32ItemID, errConv := conversions.Int64ToInt32(itemID)
-	if errConv != nil {
-		return nil, errConv
+func (provider *dofusDudeProvider) SearchConsumables(ctx context.Context, query, language string,
+) ([]dodugo.ListItem, error) {
+	resp, r, err := execute(ctx, provider, provider.client.ConsumablesAPI.
+		GetItemsConsumablesSearch(ctx, language, getDofusDudeGame(ctx)).
+		Query(query).Limit(constants.DofusDudeLimit).Execute)
+	if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
+		return nil, err
 	}
+	defer r.Body.Close()
+	return resp, nil
+}
 
-	var dodugoItem *dodugo.Resource
-	key := buildItemKey(item, fmt.Sprintf("%v", itemID), language, constants.GetEncyclopediasSource().Name)
-	if !service.getElementFromCache(ctx, key, &dodugoItem) {
-		resp, r, err := service.dofusDudeClient.ConsumablesAPI.
-			GetItemsConsumablesSingle(ctx, language, int32ItemID, constants.DofusDudeGame).Execute()
-		if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
-			return nil, err
-		}
-		defer r.Body.Close()
-		service.putElementToCache(ctx, key, resp)
-		dodugoItem = resp
+func (provider *dofusDudeProvider) SearchCosmetics(ctx context.Context, query, language string,
+) ([]dodugo.ListItem, error) {
+	resp, r, err := execute(ctx, provider, provider.client.CosmeticsAPI.
+		GetCosmeticsSearch(ctx, language, getDofusDudeGame(ctx)).
+		Query(query).Limit(constants.DofusDudeLimit).Execute)
+	if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
+		return nil, err
 	}
+	defer r.Body.Close()
+	return resp, nil
+}
 
-	return dodugoItem, nil
+func (provider *dofusDudeProvider) SearchEquipments(ctx context.Context, query, language string,
+) ([]dodugo.ListItem, error) {
+	resp, r, err := execute(ctx, provider, provider.client.EquipmentAPI.
+		GetItemsEquipmentSearch(ctx, language, getDofusDudeGame(ctx)).
+		Query(query).Limit(constants.DofusDudeLimit).Execute)
+	if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
+		return nil, err
+	}
+	defer r.Body.Close()
+	return resp, nil
 }
 
-func (service *Impl) SearchCosmetics(ctx context.Context, query,
-	language string) ([]dodugo.ListItem, error) {
-	ctx, cancel := context.WithTimeout(ctx, service.httpTimeout)
-	defer cancel()
+func (provider *dofusDudeProvider) SearchMounts(ctx context.Context, query, language string,
+) ([]dodugo.Mount, error) {
+	resp, r, err := execute(ctx, provider, provider.client.MountsAPI.
+		GetMountsSearch(ctx, language, getDofusDudeGame(ctx)).
+		Query(query).Limit(constants.DofusDudeLimit).Execute)
+	if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
+		return nil, err
+	}
+	defer r.Body.Close()
+	return resp, nil
+}
 
-	var items []dodugo.ListItem
-	key := buildListKey(item, query, language, constants.GetEncyclopediasSource().Name)
-	if !service.getElementFromCache(ctx, key, &items) {
-		resp, r, err := service.dofusDudeClient.CosmeticsAPI.
-			GetCosmeticsSearch(ctx, language, constants.DofusDudeGame).
-			Query(query).Limit(constants.DofusDudeLimit).Execute()
-		if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
-			return nil, err
-		}
-		defer r.Body.Close()
-		service.putElementToCache(ctx, key, resp)
-		items = resp
+func (provider *dofusDudeProvider) SearchQuestItems(ctx context.Context, query, language string,
+) ([]dodugo.ListItem, error) {
+	resp, r, err := execute(ctx, provider, provider.client.QuestItemsAPI.
+		GetItemsQuestSearch(ctx, language, getDofusDudeGame(ctx)).
+		Query(query).Limit(constants.DofusDudeLimit).Execute)
+	if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
+		return nil, err
 	}
+	defer r.Body.Close()
+	return resp, nil
+}
 
-	return items, nil
+func (provider *dofusDudeProvider) SearchResources(ctx context.Context, query, language string,
+) ([]dodugo.ListItem, error) {
+	resp, r, err := execute(ctx, provider, provider.client.ResourcesAPI.
+		GetItemsResourceSearch(ctx, language, getDofusDudeGame(ctx)).
+		Query(query).Limit(constants.DofusDudeLimit).Execute)
+	if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
+		return nil, err
+	}
+	defer r.Body.Close()
+	return resp, nil
 }
 
-func (service *Impl) GetCosmeticByQuery(ctx context.Context, query, language string,
-) (*dodugo.Weapon, error) {
-	ctx, cancel := context.WithTimeout(ctx, service.httpTimeout)
-	defer cancel()
+func (provider *dofusDudeProvider) SearchSets(ctx context.Context, query, language string,
+) ([]dodugo.ListEquipmentSet, error) {
+	resp, r, err := execute(ctx, provider, provider.client.SetsAPI.
+		GetSetsSearch(ctx, language, getDofusDudeGame(ctx)).
+		Query(query).Limit(constants.DofusDudeLimit).Execute)
+	if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
+		return nil, err
+	}
+	defer r.Body.Close()
+	return resp, nil
+}
 
-	values, err := service.SearchCosmetics(ctx, query, language)
+func (provider *dofusDudeProvider) SearchAlmanaxEffects(ctx context.Context, query, language string,
+) ([]dodugo.GetMetaAlmanaxBonuses200ResponseInner, error) {
+	resp, r, err := execute(ctx, provider, provider.client.MetaAPI.
+		GetMetaAlmanaxBonusesSearch(ctx, language).
+		Query(query).
+		Limit(constants.DofusDudeLimit).
+		Execute)
+	if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
+		return nil, err
+	}
+	defer r.Body.Close()
+	return resp, nil
+}
+
+func (provider *dofusDudeProvider) ListCatalog(ctx context.Context, language string,
+) (*Catalog, error) {
+	equipments, r, err := execute(ctx, provider, provider.client.EquipmentAPI.
+		GetAllItemsEquipmentList(ctx, language, getDofusDudeGame(ctx)).
+		FilterTypeNameId(constants.GetSupportedTypeEnums()).Execute)
+	if err != nil {
+		return nil, err
+	}
+	r.Body.Close()
+
+	cosmetics, r, err := execute(ctx, provider, provider.client.CosmeticsAPI.
+		GetAllCosmeticsList(ctx, language, getDofusDudeGame(ctx)).
+		FilterTypeNameId(constants.GetSupportedTypeEnums()).Execute)
+	if err != nil {
+		return nil, err
+	}
+	r.Body.Close()
+
+	mounts, r, err := execute(ctx, provider, provider.client.MountsAPI.
+		GetAllMountsList(ctx, language, getDofusDudeGame(ctx)).Execute)
 	if err != nil {
 		return nil, err
 	}
-	if len(values) == 0 {
-		return nil, ErrNotFound
+	r.Body.Close()
+
+	consumables, r, err := execute(ctx, provider, provider.client.ConsumablesAPI.
+		GetAllItemsConsumablesList(ctx, language, getDofusDudeGame(ctx)).Execute)
+	if err != nil {
+		return nil, err
 	}
+	r.Body.Close()
 
-	// We trust the omnisearch by taking the first one in the list
-	resp, err := service.GetCosmeticByID(ctx, int64(values[0].GetAnkamaId()), language)
+	questItems, r, err := execute(ctx, provider, provider.client.QuestItemsAPI.
+		GetAllItemsQuestList(ctx, language, getDofusDudeGame(ctx)).Execute)
 	if err != nil {
 		return nil, err
 	}
+	r.Body.Close()
+
+	resources, r, err := execute(ctx, provider, provider.client.ResourcesAPI.
+		GetAllItemsResourcesList(ctx, language, getDofusDudeGame(ctx)).Execute)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	return &Catalog{
+		Consumables: consumables.GetItems(),
+		Cosmetics:   cosmetics.GetItems(),
+		Equipments:  equipments.GetItems(),
+		Mounts:      mounts.GetItems(),
+		QuestItems:  questItems.GetItems(),
+		Resources:   resources.GetItems(),
+	}, nil
+}
+
+func (provider *dofusDudeProvider) ListSets(ctx context.Context, language string,
+) ([]dodugo.ListEquipmentSet, error) {
+	resp, r, err := execute(ctx, provider, provider.client.SetsAPI.
+		GetAllSetsList(ctx, language, getDofusDudeGame(ctx)).Execute)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+	return resp.GetSets(), nil
+}
 
+func (provider *dofusDudeProvider) ListAlmanaxEffects(ctx context.Context, language string,
+) ([]dodugo.GetMetaAlmanaxBonuses200ResponseInner, error) {
+	resp, r, err := execute(ctx, provider, provider.client.MetaAPI.
+		GetMetaAlmanaxBonuses(ctx, language).Execute)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
 	return resp, nil
 }
 
-func (service *Impl) GetCosmeticByID(ctx context.Context, itemID int64, language string,
-) (*dodugo.Weapon, error) {
-	ctx, cancel := context.WithTimeout(ctx, service.httpTimeout)
-	defer cancel()
+func (provider *dofusDudeProvider) FilterEquipments(ctx context.Context, typeName string, minLevel,
+	maxLevel int32, language string) ([]dodugo.ListItem, error) {
+	request := provider.client.EquipmentAPI.
+		GetItemsEquipmentList(ctx, language, getDofusDudeGame(ctx)).
+		SortLevel(constants.DofusDudeSortAscending).PageNumber(1).PageSize(-1).
+		FieldsItem([]string{"effects"})
+	if minLevel > 0 {
+		request = request.FilterMinLevel(minLevel)
+	}
+	if maxLevel > 0 {
+		request = request.FilterMaxLevel(maxLevel)
+	}
+	if typeName != "" {
+		request = request.FilterTypeNameId([]string{typeName})
+	}
 
-	int32ItemID, errConv := conversions.Int64ToInt32(itemID)
-	if errConv != nil {
-		return nil, errConv
+	resp, r, err := execute(ctx, provider, request.Execute)
+	if err != nil {
+		return nil, err
 	}
+	defer r.Body.Close()
+	return resp.GetItems(), nil
+}
 
-	var dodugoItem *dodugo.Weapon
-	key := buildItemKey(item, fmt.Sprintf("%v", itemID), language, constants.GetEncyclopediasSource().Name)
-	if !service.getElementFromCache(ctx, key, &dodugoItem) {
-		resp, r, err := service.dofusDudeClient.CosmeticsAPI.
-			GetCosmeticsSingle(ctx, language, int32ItemID, constants.DofusDudeGame).Execute()
-		if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
-			return nil, err
-		}
-		defer r.Body.Close()
-		service.putElementToCache(ctx, key, resp)
+func (provider *dofusDudeProvider) GetConsumableByID(ctx context.Context, itemID int32, language string,
+) (*dodugo.Resource, error) {
+	resp, r, err := execute(ctx, provider, provider.client.ConsumablesAPI.
+		GetItemsConsumablesSingle(ctx, language, itemID, getDofusDudeGame(ctx)).Execute)
+	if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
+		return nil, err
+	}
+	defer r.Body.Close()
+	return resp, nil
+}
+
+func (provider *dofusDudeProvider) GetCosmeticByID(ctx context.Context, itemID int32, language string,
+) (*dodugo.Weapon, error) {
+	resp, r, err := execute(ctx, provider, provider.client.CosmeticsAPI.
+		GetCosmeticsSingle(ctx, language, itemID, getDofusDudeGame(ctx)).Execute)
+	if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	var cosmetic *dodugo.Weapon
+	if resp != nil {
 		isWeapon := false
-		dodugoItem = &dodugo.Weapon{
+		cosmetic = &dodugo.Weapon{
 			AnkamaId:               resp.AnkamaId,
 			Name:                   resp.Name,
 			Description:            resp.Description,
@@ -164,385 +365,190 @@ func (service *Impl) GetCosmeticByID(ctx context.Context, itemID int64, language
 		}
 	}
 
-	return dodugoItem, nil
-}
-
-func (service *Impl) SearchEquipments(ctx context.Context, query,
-	language string) ([]dodugo.ListItem, error) {
-	ctx, cancel := context.WithTimeout(ctx, service.httpTimeout)
-	defer cancel()
-
-	var items []dodugo.ListItem
-	key := buildListKey(item, query, language, constants.GetEncyclopediasSource().Name)
-	if !service.getElementFromCache(ctx, key, &items) {
-		resp, r, err := service.dofusDudeClient.EquipmentAPI.
-			GetItemsEquipmentSearch(ctx, language, constants.DofusDudeGame).
-			Query(query).Limit(constants.DofusDudeLimit).Execute()
-		if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
-			return nil, err
-		}
-		defer r.Body.Close()
-		service.putElementToCache(ctx, key, resp)
-		items = resp
-	}
-
-	return items, nil
+	return cosmetic, nil
 }
 
-func (service *Impl) GetEquipmentByQuery(ctx context.Context, query, language string,
+func (provider *dofusDudeProvider) GetEquipmentByID(ctx context.Context, itemID int32, language string,
 ) (*dodugo.Weapon, error) {
-	ctx, cancel := context.WithTimeout(ctx, service.httpTimeout)
-	defer cancel()
-
-	values, err := service.SearchEquipments(ctx, query, language)
-	if err != nil {
+	resp, r, err := execute(ctx, provider, provider.client.EquipmentAPI.
+		GetItemsEquipmentSingle(ctx, language, itemID, getDofusDudeGame(ctx)).Execute)
+	if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
 		return nil, err
 	}
-	if len(values) == 0 {
-		return nil, ErrNotFound
-	}
-
-	// We trust the omnisearch by taking the first one in the list
-	resp, err := service.GetEquipmentByID(ctx, int64(values[0].GetAnkamaId()), language)
-	if err != nil {
-		return nil, err
-	}
-
+	defer r.Body.Close()
 	return resp, nil
 }
 
-//nolint:dupl // Complicated to be more DRY.
-func (service *Impl) GetEquipmentByID(ctx context.Context, itemID int64, language string,
-) (*dodugo.Weapon, error) {
-	ctx, cancel := context.WithTimeout(ctx, service.httpTimeout)
-	defer cancel()
-
-	int32ItemID, errConv := conversions.Int64ToInt32(itemID)
-	if errConv != nil {
-		return nil, errConv
-	}
-
-	var dodugoItem *dodugo.Weapon
-	key := buildItemKey(item, fmt.Sprintf("%v", itemID), language, constants.GetEncyclopediasSource().Name)
-	if !service.getElementFromCache(ctx, key, &dodugoItem) {
-		resp, r, err := service.dofusDudeClient.EquipmentAPI.
-			GetItemsEquipmentSingle(ctx, language, int32ItemID, constants.DofusDudeGame).Execute()
-		if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
-			return nil, err
-		}
-		defer r.Body.Close()
-		service.putElementToCache(ctx, key, resp)
-		dodugoItem = resp
+func (provider *dofusDudeProvider) GetMountByID(ctx context.Context, itemID int32, language string,
+) (*dodugo.Mount, error) {
+	resp, r, err := execute(ctx, provider, provider.client.MountsAPI.
+		GetMountsSingle(ctx, language, itemID, getDofusDudeGame(ctx)).Execute)
+	if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
+		return nil, err
 	}
-
-	return dodugoItem, nil
+	defer r.Body.Close()
+	return resp, nil
 }
 
-//nolint:dupl // Complicated to be more DRY.
-func (service *Impl) GetQuestItemByID(ctx context.Context, itemID int64, language string,
+func (provider *dofusDudeProvider) GetQuestItemByID(ctx context.Context, itemID int32, language string,
 ) (*dodugo.Resource, error) {
-	ctx, cancel := context.WithTimeout(ctx, service.httpTimeout)
-	defer cancel()
-
-	int32ItemID, errConv := conversions.Int64ToInt32(itemID)
-	if errConv != nil {
-		return nil, errConv
-	}
-
-	var dodugoItem *dodugo.Resource
-	key := buildItemKey(item, fmt.Sprintf("%v", itemID), language, constants.GetEncyclopediasSource().Name)
-	if !service.getElementFromCache(ctx, key, &dodugoItem) {
-		resp, r, err := service.dofusDudeClient.QuestItemsAPI.
-			GetItemQuestSingle(ctx, language, int32ItemID, constants.DofusDudeGame).Execute()
-		if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
-			return nil, err
-		}
-		defer r.Body.Close()
-		service.putElementToCache(ctx, key, resp)
-		dodugoItem = resp
+	resp, r, err := execute(ctx, provider, provider.client.QuestItemsAPI.
+		GetItemQuestSingle(ctx, language, itemID, getDofusDudeGame(ctx)).Execute)
+	if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
+		return nil, err
 	}
-
-	return dodugoItem, nil
+	defer r.Body.Close()
+	return resp, nil
 }
 
-//nolint:dupl // Complicated to be more DRY.
-func (service *Impl) GetResourceByID(ctx context.Context, itemID int64, language string,
+func (provider *dofusDudeProvider) GetResourceByID(ctx context.Context, itemID int32, language string,
 ) (*dodugo.Resource, error) {
-	ctx, cancel := context.WithTimeout(ctx, service.httpTimeout)
-	defer cancel()
-
-	int32ItemID, errConv := conversions.Int64ToInt32(itemID)
-	if errConv != nil {
-		return nil, errConv
+	resp, r, err := execute(ctx, provider, provider.client.ResourcesAPI.
+		GetItemsResourcesSingle(ctx, language, itemID, getDofusDudeGame(ctx)).Execute)
+	if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
+		return nil, err
 	}
+	defer r.Body.Close()
+	return resp, nil
+}
 
-	var dodugoItem *dodugo.Resource
-	key := buildItemKey(item, fmt.Sprintf("%v", itemID), language, constants.GetEncyclopediasSource().Name)
-	if !service.getElementFromCache(ctx, key, &dodugoItem) {
-		resp, r, err := service.dofusDudeClient.ResourcesAPI.
-			GetItemsResourcesSingle(ctx, language, int32ItemID, constants.DofusDudeGame).Execute()
-		if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
-			return nil, err
-		}
-		defer r.Body.Close()
-		service.putElementToCache(ctx, key, resp)
-		dodugoItem = resp
+func (provider *dofusDudeProvider) GetSetByID(ctx context.Context, setID int32, language string,
+) (*dodugo.EquipmentSet, error) {
+	resp, r, err := execute(ctx, provider, provider.client.SetsAPI.
+		GetSetsSingle(ctx, language, setID, getDofusDudeGame(ctx)).Execute)
+	if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
+		return nil, err
 	}
-
-	return dodugoItem, nil
+	defer r.Body.Close()
+	return resp, nil
 }
 
-func (service *Impl) SearchMounts(ctx context.Context, query,
-	language string) ([]dodugo.Mount, error) {
-	ctx, cancel := context.WithTimeout(ctx, service.httpTimeout)
-	defer cancel()
-
-	var items []dodugo.Mount
-	key := buildListKey(item, query, language, constants.GetEncyclopediasSource().Name)
-	if !service.getElementFromCache(ctx, key, &items) {
-		resp, r, err := service.dofusDudeClient.MountsAPI.
-			GetMountsSearch(ctx, language, constants.DofusDudeGame).
-			Query(query).Limit(constants.DofusDudeLimit).Execute()
-		if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
-			return nil, err
-		}
-		defer r.Body.Close()
-		service.putElementToCache(ctx, key, resp)
-		items = resp
+func (provider *dofusDudeProvider) GetSets(ctx context.Context) ([]dodugo.ListEquipmentSet, error) {
+	resp, r, err := execute(ctx, provider, provider.client.SetsAPI.
+		GetSetsList(ctx, constants.DofusDudeDefaultLanguage, getDofusDudeGame(ctx)).
+		PageNumber(1).PageSize(-1).FieldsSet([]string{"equipment_ids"}).
+		Execute)
+	if err != nil && r == nil {
+		return nil, err
 	}
-
-	return items, nil
+	defer r.Body.Close()
+	return resp.GetSets(), nil
 }
 
-func (service *Impl) GetMountByQuery(ctx context.Context, query, language string,
-) (*dodugo.Mount, error) {
-	ctx, cancel := context.WithTimeout(ctx, service.httpTimeout)
-	defer cancel()
-
-	values, err := service.SearchMounts(ctx, query, language)
-	if err != nil {
+func (provider *dofusDudeProvider) GetAlmanaxByDate(ctx context.Context, date, language string,
+) (*dodugo.Almanax, error) {
+	resp, r, err := execute(ctx, provider, provider.client.AlmanaxAPI.
+		GetAlmanaxDate(ctx, language, date).Execute)
+	if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
 		return nil, err
 	}
-	if len(values) == 0 {
-		return nil, ErrNotFound
-	}
+	defer r.Body.Close()
+	return resp, nil
+}
 
-	// We trust the omnisearch by taking the first one in the list
-	resp, err := service.GetMountByID(ctx, int64(values[0].GetAnkamaId()), language)
-	if err != nil {
+func (provider *dofusDudeProvider) GetAlmanaxByRange(ctx context.Context, daysDuration int32, language string,
+) ([]dodugo.Almanax, error) {
+	resp, r, err := execute(ctx, provider, provider.client.AlmanaxAPI.
+		GetAlmanaxRange(ctx, language).
+		RangeSize(daysDuration).
+		Execute)
+	if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
 		return nil, err
 	}
-
+	defer r.Body.Close()
 	return resp, nil
 }
 
-//nolint:dupl // Complicated to be more DRY.
-func (service *Impl) GetMountByID(ctx context.Context, itemID int64, language string,
-) (*dodugo.Mount, error) {
-	ctx, cancel := context.WithTimeout(ctx, service.httpTimeout)
-	defer cancel()
-
-	int32ItemID, errConv := conversions.Int64ToInt32(itemID)
-	if errConv != nil {
-		return nil, errConv
+func (provider *dofusDudeProvider) GetGameVersion(ctx context.Context) (string, error) {
+	resp, r, err := execute(ctx, provider, provider.client.MetaAPI.
+		GetMetaVersion(ctx, getDofusDudeGame(ctx)).Execute)
+	if err != nil && r == nil {
+		return "", err
 	}
-
-	var dodugoItem *dodugo.Mount
-	key := buildItemKey(item, fmt.Sprintf("%v", itemID), language, constants.GetEncyclopediasSource().Name)
-	if !service.getElementFromCache(ctx, key, &dodugoItem) {
-		resp, r, err := service.dofusDudeClient.MountsAPI.
-			GetMountsSingle(ctx, language, int32ItemID, constants.DofusDudeGame).Execute()
-		if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
-			return nil, err
-		}
-		defer r.Body.Close()
-		service.putElementToCache(ctx, key, resp)
-		dodugoItem = resp
+	defer r.Body.Close()
+	if err != nil {
+		return "", err
 	}
 
-	return dodugoItem, nil
+	return resp.GetVersion(), nil
 }
 
-func (service *Impl) SearchSets(ctx context.Context, query,
-	language string) ([]dodugo.ListEquipmentSet, error) {
-	ctx, cancel := context.WithTimeout(ctx, service.httpTimeout)
-	defer cancel()
+// execute calls DofusDude through the circuit breaker, retrying 5xx responses
+// and timeouts with a jittered exponential backoff.
+func execute[T any](ctx context.Context, provider *dofusDudeProvider,
+	call func() (T, *http.Response, error)) (T, *http.Response, error) {
+	var resp T
+	if !provider.breaker.Allow() {
+		return resp, nil, breakers.ErrCircuitOpen
+	}
 
-	var sets []dodugo.ListEquipmentSet
-	key := buildListKey(set, query, language, constants.GetEncyclopediasSource().Name)
-	if !service.getElementFromCache(ctx, key, &sets) {
-		resp, r, err := service.dofusDudeClient.SetsAPI.
-			GetSetsSearch(ctx, language, constants.DofusDudeGame).
-			Query(query).Limit(constants.DofusDudeLimit).Execute()
-		if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
-			return nil, err
+	for attempt := 0; ; attempt++ {
+		resp, r, err := call()
+		if isLocalRejection(r, err) {
+			provider.breaker.Release()
+			return resp, r, err
 		}
-		defer r.Body.Close()
-		service.putElementToCache(ctx, key, resp)
-		sets = resp
-	}
 
-	return sets, nil
-}
+		if !isUpstreamFailure(r, err) {
+			provider.breaker.Success()
+			return resp, r, err
+		}
 
-func (service *Impl) GetSetByQuery(ctx context.Context, query, language string,
-) (*dodugo.EquipmentSet, error) {
-	ctx, cancel := context.WithTimeout(ctx, service.httpTimeout)
-	defer cancel()
+		if attempt >= provider.retries || !isRetryable(r, err) || !provider.waitBeforeRetry(ctx, attempt) {
+			provider.breaker.Failure()
+			return resp, r, err
+		}
 
-	values, err := service.SearchSets(ctx, query, language)
-	if err != nil {
-		return nil, err
-	}
-	if len(values) == 0 {
-		return nil, ErrNotFound
-	}
+		if r != nil {
+			r.Body.Close()
+		}
 
-	// We trust the omnisearch by taking the first one in the list
-	resp, err := service.GetSetByID(ctx, int64(values[0].GetAnkamaId()), language)
-	if err != nil {
-		return nil, err
+		provider.retryCounter.Inc()
+		log.Debug().Err(err).
+			Str(constants.LogSource, constants.DofusDudeSourceName).
+			Msgf("Retrying call, attempt %v/%v", attempt+1, provider.retries)
 	}
-
-	return resp, nil
 }
 
-//nolint:dupl // Complicated to be more DRY.
-func (service *Impl) GetSetByID(ctx context.Context, setID int64, language string,
-) (*dodugo.EquipmentSet, error) {
-	ctx, cancel := context.WithTimeout(ctx, service.httpTimeout)
-	defer cancel()
-
-	int32ItemID, errConv := conversions.Int64ToInt32(setID)
-	if errConv != nil {
-		return nil, errConv
+func (provider *dofusDudeProvider) waitBeforeRetry(ctx context.Context, attempt int) bool {
+	backoff := provider.backoff << attempt
+	if backoff <= 0 || backoff > provider.maxBackoff {
+		backoff = provider.maxBackoff
 	}
 
-	var dodugoSet *dodugo.EquipmentSet
-	key := buildItemKey(set, fmt.Sprintf("%v", setID), language, constants.GetEncyclopediasSource().Name)
-	if !service.getElementFromCache(ctx, key, &dodugoSet) {
-		resp, r, err := service.dofusDudeClient.SetsAPI.
-			GetSetsSingle(ctx, language, int32ItemID, constants.DofusDudeGame).Execute()
-		if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
-			return nil, err
-		}
-		defer r.Body.Close()
-		service.putElementToCache(ctx, key, resp)
-		dodugoSet = resp
-	}
-
-	return dodugoSet, nil
-}
+	//nolint:gosec // Jitter does not need a cryptographically secure generator.
+	timer := time.NewTimer(time.Duration(rand.Int64N(int64(backoff) + 1)))
+	defer timer.Stop()
 
-// Returns sets with minimal informations. No cache applied here.
-func (service *Impl) GetSets(ctx context.Context) ([]dodugo.ListEquipmentSet, error) {
-	ctx, cancel := context.WithTimeout(ctx, service.httpTimeout)
-	defer cancel()
-
-	resp, r, err := service.dofusDudeClient.SetsAPI.
-		GetSetsList(ctx, constants.DofusDudeDefaultLanguage, constants.DofusDudeGame).
-		PageNumber(1).PageSize(-1).FieldsSet([]string{"equipment_ids"}).
-		Execute()
-	if err != nil && r == nil {
-		return nil, err
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
 	}
-	defer r.Body.Close()
-
-	return resp.GetSets(), nil
 }
 
-func (service *Impl) SearchAlmanaxEffects(ctx context.Context, query,
-	language string) ([]dodugo.GetMetaAlmanaxBonuses200ResponseInner, error) {
-	ctx, cancel := context.WithTimeout(ctx, service.httpTimeout)
-	defer cancel()
-
-	var effects []dodugo.GetMetaAlmanaxBonuses200ResponseInner
-	key := buildListKey(almanaxEffect, query, language, constants.GetEncyclopediasSource().Name)
-	if !service.getElementFromCache(ctx, key, &effects) {
-		resp, r, err := service.dofusDudeClient.MetaAPI.
-			GetMetaAlmanaxBonusesSearch(ctx, language).
-			Query(query).
-			Limit(constants.DofusDudeLimit).
-			Execute()
-		if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
-			return nil, err
-		}
-		defer r.Body.Close()
-		service.putElementToCache(ctx, key, resp)
-		effects = resp
-	}
-
-	return effects, nil
+// isLocalRejection is true for calls which did not get an answer from DofusDude for local reasons,
+// such as a canceled context or a request given up while waiting for the rate limiter.
+func isLocalRejection(r *http.Response, err error) bool {
+	return r == nil && (errors.Is(err, context.Canceled) || errors.Is(err, limiters.ErrUpstreamBusy))
 }
 
-func (service *Impl) GetAlmanaxByDate(ctx context.Context, date time.Time, language string,
-) (*dodugo.Almanax, error) {
-	ctx, cancel := context.WithTimeout(ctx, service.httpTimeout)
-	defer cancel()
-
-	var dodugoAlmanax *dodugo.Almanax
-	dodugoAlmanaxDate := date.Format(constants.DofusDudeAlmanaxDateFormat)
-	key := buildItemKey(almanax, dodugoAlmanaxDate, language, constants.GetEncyclopediasSource().Name)
-	if !service.getElementFromCache(ctx, key, &dodugoAlmanax) {
-		resp, r, err := service.dofusDudeClient.AlmanaxAPI.
-			GetAlmanaxDate(ctx, language, dodugoAlmanaxDate).Execute()
-		if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
-			return nil, err
-		}
-		defer r.Body.Close()
-		service.putElementToCache(ctx, key, resp)
-		dodugoAlmanax = resp
-	}
-
-	if dodugoAlmanax == nil {
-		currentYear := time.Now().Year()
-		if currentYear != date.Year() {
-			log.Warn().
-				Str(constants.LogDate, dodugoAlmanaxDate).
-				Msgf("DofusDude API returns 404 NOT_FOUND for specific date, continuing with closest date...")
-			fallbackDate := time.Date(currentYear, date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
-			fallbackAlmanax, errFallback := service.GetAlmanaxByDate(ctx, fallbackDate, language)
-			if fallbackAlmanax != nil {
-				fallbackAlmanax.SetDate(dodugoAlmanaxDate)
-				service.putElementToCache(ctx, key, fallbackAlmanax)
-			}
-			return fallbackAlmanax, errFallback
-		}
-
-		log.Error().
-			Str(constants.LogDate, dodugoAlmanaxDate).
-			Msgf("DofusDude API returns 404 NOT_FOUND for a close date, continuing with nil almanax...")
+// isUpstreamFailure reports whether DofusDude looks unhealthy; a 404 is a valid answer
+// and local throttling says nothing about the upstream health.
+func isUpstreamFailure(r *http.Response, err error) bool {
+	if r != nil {
+		return r.StatusCode >= http.StatusInternalServerError
 	}
 
-	return dodugoAlmanax, nil
+	return err != nil
 }
 
-func (service *Impl) GetAlmanaxByRange(ctx context.Context, daysDuration int64, language string,
-) ([]dodugo.Almanax, error) {
-	ctx, cancel := context.WithTimeout(ctx, service.httpTimeout)
-	defer cancel()
-
-	int32DaysDuration, errConv := conversions.Int64ToInt32(daysDuration)
-	if errConv != nil {
-		return nil, errConv
-	}
-
-	var dodugoAlmanax []dodugo.Almanax
-	dodugoAlmanaxDate := time.Now().Format(constants.DofusDudeAlmanaxDateFormat)
-	key := buildItemKey(almanaxRange, fmt.Sprintf("%v_%v", dodugoAlmanaxDate, daysDuration),
-		language, constants.GetEncyclopediasSource().Name)
-	if !service.getElementFromCache(ctx, key, &dodugoAlmanax) {
-		resp, r, err := service.dofusDudeClient.AlmanaxAPI.
-			GetAlmanaxRange(ctx, language).
-			RangeSize(int32DaysDuration).
-			Execute()
-		if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
-			return nil, err
-		}
-		defer r.Body.Close()
-		service.putElementToCache(ctx, key, resp)
-		dodugoAlmanax = resp
+func isRetryable(r *http.Response, err error) bool {
+	if r != nil {
+		return r.StatusCode >= http.StatusInternalServerError
 	}
 
-	return dodugoAlmanax, nil
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout())
 }
@@ -13,9 +13,18 @@ func (service *Impl) ListenGameEvent(handler GameEventHandler) {
 	service.eventHandlers = append(service.eventHandlers, handler)
 }
 
-func (service *Impl) checkGameVersion() {
-	ctx := context.Background()
-	game := amqp.Game_DOFUS_GAME
+func (service *Impl) ListenCatalog(handler CatalogHandler) {
+	service.catalogHandlers = append(service.catalogHandlers, handler)
+}
+
+func (service *Impl) checkGameVersions() {
+	for game := range constants.GetDofusDudeGames() {
+		service.checkGameVersion(game)
+	}
+}
+
+func (service *Impl) checkGameVersion(game amqp.Game) {
+	ctx := WithGame(context.Background(), game)
 	log.Info().Msgf("Checking %v version", game)
 
 	gameVersion, errGetDB := service.gameRepo.GetGameVersion(game)
@@ -24,19 +33,16 @@ func (service *Impl) checkGameVersion() {
 		return
 	}
 
-	resp, r, err := service.dofusDudeClient.MetaAPI.GetMetaVersion(ctx, constants.DofusDudeGame).Execute()
-	if err != nil && r == nil {
-		log.Error().Err(err).Msgf("Cannot retrieve %v version from source, trying later...", game)
-		return
-	}
-	defer r.Body.Close()
-	if err != nil {
-		log.Error().Err(err).Msgf("Cannot retrieve %v version from source, trying later...", game)
+	latestGameVersion, errVersion := service.getLatestGameVersion(ctx)
+	if errVersion != nil {
+		log.Error().Err(errVersion).Msgf("Cannot retrieve %v version from source, trying later...", game)
 		return
 	}
 
+	// Other instances may already have saved the latest version: the cache namespace is switched anyway.
+	service.switchCacheVersion(ctx, game, latestGameVersion)
+
 	currentVersion := gameVersion.Version
-	latestGameVersion := resp.GetVersion()
 	if currentVersion == latestGameVersion {
 		log.Info().Msgf("No change in %v version, trying later...", game)
 		return
@@ -50,11 +56,18 @@ func (service *Impl) checkGameVersion() {
 
 	log.Info().Msgf("%v version goes from '%v' to '%v'", game, currentVersion, latestGameVersion)
 	for _, handler := range service.eventHandlers {
-		go emitGameEvent(handler, latestGameVersion)
+		go emitGameEvent(handler, game, latestGameVersion)
 	}
 }
 
-func emitGameEvent(handler GameEventHandler, gameVersion string) {
+func (service *Impl) getLatestGameVersion(ctx context.Context) (string, error) {
+	return fetchUncached(ctx, service, item, service.httpTimeout,
+		func(ctx context.Context, provider Provider) (string, error) {
+			return provider.GetGameVersion(ctx)
+		})
+}
+
+func emitGameEvent(handler GameEventHandler, game amqp.Game, gameVersion string) {
 	defer func() {
 		err := recover()
 		if err != nil {
@@ -63,5 +76,5 @@ func emitGameEvent(handler GameEventHandler, gameVersion string) {
 		}
 	}()
 
-	handler(gameVersion)
+	handler(game, gameVersion)
 }
@@ -0,0 +1,213 @@
+package sources
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/dofusdude/dodugo"
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+	"github.com/kaellybot/kaelly-encyclopedia/utils/rankings"
+	"github.com/rs/zerolog/log"
+)
+
+func (service *Impl) AutocompleteAnyItems(ctx context.Context, query, language string,
+) ([]dodugo.GameSearch, error) {
+	index := service.getLanguageIndex(GetGame(ctx), language)
+	if index == nil || index.items == nil {
+		return service.SearchAnyItems(ctx, query, language)
+	}
+
+	return index.items.search(query), nil
+}
+
+func (service *Impl) AutocompleteSets(ctx context.Context, query, language string,
+) ([]dodugo.ListEquipmentSet, error) {
+	index := service.getLanguageIndex(GetGame(ctx), language)
+	if index == nil || index.sets == nil {
+		return service.SearchSets(ctx, query, language)
+	}
+
+	return index.sets.search(query), nil
+}
+
+func (service *Impl) AutocompleteAlmanaxEffects(ctx context.Context, query, language string,
+) ([]dodugo.GetMetaAlmanaxBonuses200ResponseInner, error) {
+	index := service.getLanguageIndex(GetGame(ctx), language)
+	if index == nil || index.almanaxEffects == nil {
+		return service.SearchAlmanaxEffects(ctx, query, language)
+	}
+
+	return index.almanaxEffects.search(query), nil
+}
+
+func (service *Impl) getLanguageIndex(game amqp.Game, language string) *languageIndex {
+	service.indexMutex.RLock()
+	defer service.indexMutex.RUnlock()
+	return service.indexes[game][language]
+}
+
+// rebuildIndexes retrieves full game catalogs in every language and swaps its autocomplete indexes.
+// A catalog which cannot be retrieved is left out, its autocompletion relying on DofusDude search.
+func (service *Impl) rebuildIndexes(game amqp.Game) {
+	log.Info().Msgf("Building %v autocomplete indexes...", game)
+	ctx := WithGame(context.Background(), game)
+	indexes := make(map[string]*languageIndex)
+	for _, language := range constants.GetLanguages() {
+		if _, found := indexes[language]; found {
+			continue
+		}
+
+		index := languageIndex{}
+		catalog, errCatalog := fetchUncached(ctx, service, item, service.indexTimeout,
+			func(ctx context.Context, provider Provider) (*Catalog, error) {
+				return provider.ListCatalog(ctx, language)
+			})
+		if errCatalog == nil && catalog != nil {
+			index.items = newAutocompleteIndex(newCatalogGameSearches(catalog), getGameSearchName)
+			for _, handler := range service.catalogHandlers {
+				handler(game, language, catalog)
+			}
+		}
+
+		sets, errSets := fetchUncached(ctx, service, set, service.indexTimeout,
+			func(ctx context.Context, provider Provider) ([]dodugo.ListEquipmentSet, error) {
+				return provider.ListSets(ctx, language)
+			})
+		if errSets == nil {
+			index.sets = newAutocompleteIndex(sets, getListSetName)
+		}
+
+		effects, errEffects := fetchUncached(ctx, service, almanaxEffect, service.indexTimeout,
+			func(ctx context.Context, provider Provider) ([]dodugo.GetMetaAlmanaxBonuses200ResponseInner, error) {
+				return provider.ListAlmanaxEffects(ctx, language)
+			})
+		if errEffects == nil {
+			index.almanaxEffects = newAutocompleteIndex(effects, getAlmanaxBonusName)
+		}
+
+		indexes[language] = &index
+	}
+
+	service.indexMutex.Lock()
+	service.indexes[game] = indexes
+	service.indexMutex.Unlock()
+	log.Info().Msgf("%v autocomplete indexes built", game)
+}
+
+func newCatalogGameSearches(catalog *Catalog) []dodugo.GameSearch {
+	items := make([]dodugo.GameSearch, 0)
+	listItemIndexes := []struct {
+		searchIndex string
+		items       []dodugo.ListItem
+	}{
+		{searchIndex: "items-equipment", items: catalog.Equipments},
+		{searchIndex: "items-cosmetics", items: catalog.Cosmetics},
+		{searchIndex: "items-consumables", items: catalog.Consumables},
+		{searchIndex: "items-quest_items", items: catalog.QuestItems},
+		{searchIndex: "items-resources", items: catalog.Resources},
+	}
+	for _, listItemIndex := range listItemIndexes {
+		for _, item := range listItemIndex.items {
+			items = append(items, newGameSearch(item.AnkamaId, item.Name, listItemIndex.searchIndex,
+				item.Level, item.ImageUrls))
+		}
+	}
+	for _, mount := range catalog.Mounts {
+		items = append(items, newGameSearch(mount.AnkamaId, mount.Name, "mounts", nil, mount.ImageUrls))
+	}
+
+	return items
+}
+
+func newAutocompleteIndex[T any](values []T, getName func(T) string) *autocompleteIndex[T] {
+	sortedValues := make([]T, len(values))
+	copy(sortedValues, values)
+	sort.SliceStable(sortedValues, func(i, j int) bool {
+		return rankings.Normalize(getName(sortedValues[i])) < rankings.Normalize(getName(sortedValues[j]))
+	})
+
+	index := autocompleteIndex[T]{
+		values:   sortedValues,
+		names:    make([]string, len(sortedValues)),
+		trigrams: make(map[string][]int),
+		prefixes: make(map[string][]int),
+		getName:  getName,
+	}
+
+	for i, value := range sortedValues {
+		name := rankings.Normalize(getName(value))
+		index.names[i] = name
+
+		keys := make(map[string]struct{})
+		runes := []rune(name)
+		for start := 0; start+trigramSize <= len(runes); start++ {
+			keys[string(runes[start:start+trigramSize])] = struct{}{}
+		}
+		for key := range keys {
+			index.trigrams[key] = append(index.trigrams[key], i)
+		}
+
+		keys = make(map[string]struct{})
+		for _, word := range strings.Fields(name) {
+			wordRunes := []rune(word)
+			for length := 1; length < trigramSize && length <= len(wordRunes); length++ {
+				keys[string(wordRunes[:length])] = struct{}{}
+			}
+		}
+		for key := range keys {
+			index.prefixes[key] = append(index.prefixes[key], i)
+		}
+	}
+
+	return &index
+}
+
+// search returns every value containing the query, best matches first.
+// Queries shorter than a trigram are matched against word prefixes.
+func (index *autocompleteIndex[T]) search(query string) []T {
+	normalizedQuery := rankings.Normalize(query)
+	runes := []rune(normalizedQuery)
+
+	var candidates []int
+	switch {
+	case len(runes) == 0:
+		return index.values
+	case len(runes) < trigramSize:
+		candidates = index.prefixes[normalizedQuery]
+	default:
+		candidates = index.trigrams[string(runes[:trigramSize])]
+		for start := 1; start+trigramSize <= len(runes) && len(candidates) > 0; start++ {
+			candidates = intersect(candidates, index.trigrams[string(runes[start:start+trigramSize])])
+		}
+	}
+
+	results := make([]T, 0)
+	for _, candidate := range candidates {
+		if strings.Contains(index.names[candidate], normalizedQuery) {
+			results = append(results, index.values[candidate])
+		}
+	}
+
+	rankings.SortByMatch(query, results, index.getName)
+	return results
+}
+
+func intersect(sortedLeft, sortedRight []int) []int {
+	result := make([]int, 0, min(len(sortedLeft), len(sortedRight)))
+	for i, j := 0, 0; i < len(sortedLeft) && j < len(sortedRight); {
+		switch {
+		case sortedLeft[i] < sortedRight[j]:
+			i++
+		case sortedLeft[i] > sortedRight[j]:
+			j++
+		default:
+			result = append(result, sortedLeft[i])
+			i++
+			j++
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,79 @@
+package sources
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestIntersect(t *testing.T) {
+	tests := []struct {
+		name     string
+		left     []int
+		right    []int
+		expected []int
+	}{
+		{name: "empty", left: []int{}, right: []int{1, 2}, expected: []int{}},
+		{name: "disjoint", left: []int{1, 3}, right: []int{2, 4}, expected: []int{}},
+		{name: "overlapping", left: []int{1, 2, 5, 8}, right: []int{2, 3, 8, 9}, expected: []int{2, 8}},
+		{name: "identical", left: []int{4, 7}, right: []int{4, 7}, expected: []int{4, 7}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if result := intersect(test.left, test.right); !slices.Equal(result, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestAutocompleteIndexSearch(t *testing.T) {
+	index := newAutocompleteIndex([]string{
+		"Gelanneau", "Anneau Gelé", "Coiffe du Bouftou", "Gelano", "Bottes du Bouftou",
+	}, func(name string) string { return name })
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []string
+	}{
+		{
+			name:     "empty query returns every value sorted by name",
+			query:    " ",
+			expected: []string{"Anneau Gelé", "Bottes du Bouftou", "Coiffe du Bouftou", "Gelanneau", "Gelano"},
+		},
+		{
+			name:     "short query matches word prefixes",
+			query:    "Ge",
+			expected: []string{"Gelano", "Gelanneau", "Anneau Gelé"},
+		},
+		{
+			name:     "trigrams match anywhere in the name, ignoring accents and case",
+			query:    "ANNEAU",
+			expected: []string{"Anneau Gelé", "Gelanneau"},
+		},
+		{
+			name:     "every trigram must match",
+			query:    "bouftou",
+			expected: []string{"Bottes du Bouftou", "Coiffe du Bouftou"},
+		},
+		{
+			name:     "trigrams in the wrong order do not match",
+			query:    "ouftbou",
+			expected: []string{},
+		},
+		{
+			name:     "no match",
+			query:    "dofus",
+			expected: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if results := index.search(test.query); !slices.Equal(results, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, results)
+			}
+		})
+	}
+}
@@ -0,0 +1,313 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dofusdude/dodugo"
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+	"github.com/kaellybot/kaelly-encyclopedia/utils/conversions"
+	"github.com/kaellybot/kaelly-encyclopedia/utils/rankings"
+	"github.com/rs/zerolog/log"
+)
+
+func (service *Impl) GetItemType(itemType string) amqp.ItemType {
+	amqpItemType, found := service.itemTypes[itemType]
+	if !found {
+		log.Warn().
+			Str(constants.LogItemType, itemType).
+			Msgf("Cannot find dofusDude itemType match, returning amqp.ItemType_ANY_ITEM")
+		return amqp.ItemType_ANY_ITEM_TYPE
+	}
+
+	return amqpItemType
+}
+
+func (service *Impl) SearchAnyItems(ctx context.Context, query,
+	language string) ([]dodugo.GameSearch, error) {
+	items, _, err := fetch(ctx, service, item,
+		func(source string) string {
+			return buildItemListKey(amqp.ItemType_ANY_ITEM_TYPE, query, language, source)
+		},
+		func(ctx context.Context, provider Provider) ([]dodugo.GameSearch, error) {
+			return provider.SearchAnyItems(ctx, query, language)
+		})
+	return items, err
+}
+
+func (service *Impl) SearchConsumables(ctx context.Context, query,
+	language string) ([]dodugo.ListItem, error) {
+	return service.searchItems(ctx, amqp.ItemType_CONSUMABLE_TYPE, query, language,
+		func(ctx context.Context, provider Provider) ([]dodugo.ListItem, error) {
+			return provider.SearchConsumables(ctx, query, language)
+		})
+}
+
+func (service *Impl) GetConsumableByQuery(ctx context.Context, query, language string,
+) (*dodugo.Resource, error) {
+	return service.getResourceByQuery(ctx, query, language,
+		service.SearchConsumables, service.GetConsumableByID)
+}
+
+func (service *Impl) GetConsumableByID(ctx context.Context, itemID int64, language string,
+) (*dodugo.Resource, error) {
+	int32ItemID, errConv := conversions.Int64ToInt32(itemID)
+	if errConv != nil {
+		return nil, errConv
+	}
+
+	trackItemRequest(ctx, amqp.ItemType_CONSUMABLE_TYPE, itemID, language)
+
+	dodugoItem, _, err := fetch(ctx, service, item,
+		func(source string) string { return buildItemKey(item, fmt.Sprintf("%v", itemID), language, source) },
+		func(ctx context.Context, provider Provider) (*dodugo.Resource, error) {
+			return provider.GetConsumableByID(ctx, int32ItemID, language)
+		})
+	return dodugoItem, err
+}
+
+func (service *Impl) SearchCosmetics(ctx context.Context, query,
+	language string) ([]dodugo.ListItem, error) {
+	return service.searchItems(ctx, amqp.ItemType_COSMETIC_TYPE, query, language,
+		func(ctx context.Context, provider Provider) ([]dodugo.ListItem, error) {
+			return provider.SearchCosmetics(ctx, query, language)
+		})
+}
+
+func (service *Impl) GetCosmeticByQuery(ctx context.Context, query, language string,
+) (*dodugo.Weapon, error) {
+	values, err := service.SearchCosmetics(ctx, query, language)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, ErrNotFound
+	}
+
+	best := values[rankings.BestMatch(query, values, getListItemName)]
+	resp, err := service.GetCosmeticByID(ctx, int64(best.GetAnkamaId()), language)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (service *Impl) GetCosmeticByID(ctx context.Context, itemID int64, language string,
+) (*dodugo.Weapon, error) {
+	int32ItemID, errConv := conversions.Int64ToInt32(itemID)
+	if errConv != nil {
+		return nil, errConv
+	}
+
+	trackItemRequest(ctx, amqp.ItemType_COSMETIC_TYPE, itemID, language)
+
+	dodugoItem, _, err := fetch(ctx, service, item,
+		func(source string) string { return buildItemKey(item, fmt.Sprintf("%v", itemID), language, source) },
+		func(ctx context.Context, provider Provider) (*dodugo.Weapon, error) {
+			return provider.GetCosmeticByID(ctx, int32ItemID, language)
+		})
+	return dodugoItem, err
+}
+
+func (service *Impl) SearchEquipments(ctx context.Context, query,
+	language string) ([]dodugo.ListItem, error) {
+	return service.searchItems(ctx, amqp.ItemType_EQUIPMENT_TYPE, query, language,
+		func(ctx context.Context, provider Provider) ([]dodugo.ListItem, error) {
+			return provider.SearchEquipments(ctx, query, language)
+		})
+}
+
+func (service *Impl) GetEquipmentByQuery(ctx context.Context, query, language string,
+) (*dodugo.Weapon, error) {
+	values, err := service.SearchEquipments(ctx, query, language)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, ErrNotFound
+	}
+
+	best := values[rankings.BestMatch(query, values, getListItemName)]
+	resp, err := service.GetEquipmentByID(ctx, int64(best.GetAnkamaId()), language)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (service *Impl) GetEquipmentByID(ctx context.Context, itemID int64, language string,
+) (*dodugo.Weapon, error) {
+	int32ItemID, errConv := conversions.Int64ToInt32(itemID)
+	if errConv != nil {
+		return nil, errConv
+	}
+
+	trackItemRequest(ctx, amqp.ItemType_EQUIPMENT_TYPE, itemID, language)
+
+	dodugoItem, _, err := fetch(ctx, service, item,
+		func(source string) string { return buildItemKey(item, fmt.Sprintf("%v", itemID), language, source) },
+		func(ctx context.Context, provider Provider) (*dodugo.Weapon, error) {
+			return provider.GetEquipmentByID(ctx, int32ItemID, language)
+		})
+	return dodugoItem, err
+}
+
+func (service *Impl) SearchQuestItems(ctx context.Context, query,
+	language string) ([]dodugo.ListItem, error) {
+	return service.searchItems(ctx, amqp.ItemType_QUEST_ITEM_TYPE, query, language,
+		func(ctx context.Context, provider Provider) ([]dodugo.ListItem, error) {
+			return provider.SearchQuestItems(ctx, query, language)
+		})
+}
+
+func (service *Impl) GetQuestItemByQuery(ctx context.Context, query, language string,
+) (*dodugo.Resource, error) {
+	return service.getResourceByQuery(ctx, query, language,
+		service.SearchQuestItems, service.GetQuestItemByID)
+}
+
+func (service *Impl) GetQuestItemByID(ctx context.Context, itemID int64, language string,
+) (*dodugo.Resource, error) {
+	int32ItemID, errConv := conversions.Int64ToInt32(itemID)
+	if errConv != nil {
+		return nil, errConv
+	}
+
+	trackItemRequest(ctx, amqp.ItemType_QUEST_ITEM_TYPE, itemID, language)
+
+	dodugoItem, _, err := fetch(ctx, service, item,
+		func(source string) string { return buildItemKey(item, fmt.Sprintf("%v", itemID), language, source) },
+		func(ctx context.Context, provider Provider) (*dodugo.Resource, error) {
+			return provider.GetQuestItemByID(ctx, int32ItemID, language)
+		})
+	return dodugoItem, err
+}
+
+func (service *Impl) SearchResources(ctx context.Context, query,
+	language string) ([]dodugo.ListItem, error) {
+	return service.searchItems(ctx, amqp.ItemType_RESOURCE_TYPE, query, language,
+		func(ctx context.Context, provider Provider) ([]dodugo.ListItem, error) {
+			return provider.SearchResources(ctx, query, language)
+		})
+}
+
+func (service *Impl) GetResourceByQuery(ctx context.Context, query, language string,
+) (*dodugo.Resource, error) {
+	return service.getResourceByQuery(ctx, query, language,
+		service.SearchResources, service.GetResourceByID)
+}
+
+func (service *Impl) GetResourceByID(ctx context.Context, itemID int64, language string,
+) (*dodugo.Resource, error) {
+	int32ItemID, errConv := conversions.Int64ToInt32(itemID)
+	if errConv != nil {
+		return nil, errConv
+	}
+
+	trackItemRequest(ctx, amqp.ItemType_RESOURCE_TYPE, itemID, language)
+
+	dodugoItem, _, err := fetch(ctx, service, item,
+		func(source string) string { return buildItemKey(item, fmt.Sprintf("%v", itemID), language, source) },
+		func(ctx context.Context, provider Provider) (*dodugo.Resource, error) {
+			return provider.GetResourceByID(ctx, int32ItemID, language)
+		})
+	return dodugoItem, err
+}
+
+func (service *Impl) SearchMounts(ctx context.Context, query,
+	language string) ([]dodugo.Mount, error) {
+	items, _, err := fetch(ctx, service, item,
+		func(source string) string { return buildItemListKey(amqp.ItemType_MOUNT_TYPE, query, language, source) },
+		func(ctx context.Context, provider Provider) ([]dodugo.Mount, error) {
+			return provider.SearchMounts(ctx, query, language)
+		})
+	return items, err
+}
+
+func (service *Impl) GetMountByQuery(ctx context.Context, query, language string,
+) (*dodugo.Mount, error) {
+	values, err := service.SearchMounts(ctx, query, language)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, ErrNotFound
+	}
+
+	best := values[rankings.BestMatch(query, values, getMountName)]
+	resp, err := service.GetMountByID(ctx, int64(best.GetAnkamaId()), language)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (service *Impl) GetMountByID(ctx context.Context, itemID int64, language string,
+) (*dodugo.Mount, error) {
+	int32ItemID, errConv := conversions.Int64ToInt32(itemID)
+	if errConv != nil {
+		return nil, errConv
+	}
+
+	trackItemRequest(ctx, amqp.ItemType_MOUNT_TYPE, itemID, language)
+
+	dodugoItem, _, err := fetch(ctx, service, item,
+		func(source string) string { return buildItemKey(item, fmt.Sprintf("%v", itemID), language, source) },
+		func(ctx context.Context, provider Provider) (*dodugo.Mount, error) {
+			return provider.GetMountByID(ctx, int32ItemID, language)
+		})
+	return dodugoItem, err
+}
+
+func (service *Impl) FilterEquipments(ctx context.Context, equipmentType amqp.EquipmentType,
+	minLevel, maxLevel int64, language string) ([]dodugo.ListItem, error) {
+	int32MinLevel, errConv := conversions.Int64ToInt32(minLevel)
+	if errConv != nil {
+		return nil, errConv
+	}
+	int32MaxLevel, errConv := conversions.Int64ToInt32(maxLevel)
+	if errConv != nil {
+		return nil, errConv
+	}
+
+	// Unknown types are not filtered upstream, the caller still filters by type.
+	typeName := constants.GetDofusDudeEquipmentTypes()[equipmentType]
+	items, _, err := fetch(ctx, service, item,
+		func(source string) string {
+			return buildEquipmentFilterKey(typeName, minLevel, maxLevel, language, source)
+		},
+		func(ctx context.Context, provider Provider) ([]dodugo.ListItem, error) {
+			return provider.FilterEquipments(ctx, typeName, int32MinLevel, int32MaxLevel, language)
+		})
+	return items, err
+}
+
+func (service *Impl) searchItems(ctx context.Context, itemType amqp.ItemType, query, language string,
+	call func(ctx context.Context, provider Provider) ([]dodugo.ListItem, error),
+) ([]dodugo.ListItem, error) {
+	items, _, err := fetch(ctx, service, item,
+		func(source string) string { return buildItemListKey(itemType, query, language, source) },
+		call)
+	return items, err
+}
+
+// getResourceByQuery retrieves the best match among consumables, quest items or resources.
+func (service *Impl) getResourceByQuery(ctx context.Context, query, language string,
+	search func(ctx context.Context, query, language string) ([]dodugo.ListItem, error),
+	getByID func(ctx context.Context, itemID int64, language string) (*dodugo.Resource, error),
+) (*dodugo.Resource, error) {
+	values, err := search(ctx, query, language)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, ErrNotFound
+	}
+
+	best := values[rankings.BestMatch(query, values, getListItemName)]
+	return getByID(ctx, int64(best.GetAnkamaId()), language)
+}
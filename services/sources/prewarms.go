@@ -0,0 +1,137 @@
+package sources
+
+import (
+	"context"
+	"sort"
+
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+	"github.com/rs/zerolog/log"
+)
+
+// PrewarmPopularItems closes the current request window
+// and puts its most requested items into cache.
+func (service *Impl) PrewarmPopularItems(ctx context.Context) {
+	service.popularityMutex.Lock()
+	popularItems := make([]itemRequest, 0, len(service.requestCounts))
+	for request := range service.requestCounts {
+		popularItems = append(popularItems, request)
+	}
+
+	sort.SliceStable(popularItems, func(i, j int) bool {
+		return service.requestCounts[popularItems[i]] > service.requestCounts[popularItems[j]]
+	})
+
+	if len(popularItems) > service.prewarmItemCount {
+		popularItems = popularItems[:service.prewarmItemCount]
+	}
+
+	service.popularItems = popularItems
+	service.requestCounts = make(map[itemRequest]int)
+	service.popularityMutex.Unlock()
+
+	service.prewarmItems(ctx, popularItems)
+}
+
+func (service *Impl) prewarmItems(ctx context.Context, requests []itemRequest) {
+	log.Info().
+		Int(constants.LogEntityCount, len(requests)).
+		Msgf("Prewarming cache with popular items...")
+
+	getItemFuncs := map[amqp.ItemType]func(ctx context.Context, itemID int64, language string) error{
+		amqp.ItemType_CONSUMABLE_TYPE: func(ctx context.Context, itemID int64, language string) error {
+			_, err := service.GetConsumableByID(ctx, itemID, language)
+			return err
+		},
+		amqp.ItemType_COSMETIC_TYPE: func(ctx context.Context, itemID int64, language string) error {
+			_, err := service.GetCosmeticByID(ctx, itemID, language)
+			return err
+		},
+		amqp.ItemType_EQUIPMENT_TYPE: func(ctx context.Context, itemID int64, language string) error {
+			_, err := service.GetEquipmentByID(ctx, itemID, language)
+			return err
+		},
+		amqp.ItemType_MOUNT_TYPE: func(ctx context.Context, itemID int64, language string) error {
+			_, err := service.GetMountByID(ctx, itemID, language)
+			return err
+		},
+		amqp.ItemType_QUEST_ITEM_TYPE: func(ctx context.Context, itemID int64, language string) error {
+			_, err := service.GetQuestItemByID(ctx, itemID, language)
+			return err
+		},
+		amqp.ItemType_RESOURCE_TYPE: func(ctx context.Context, itemID int64, language string) error {
+			_, err := service.GetResourceByID(ctx, itemID, language)
+			return err
+		},
+		amqp.ItemType_SET_TYPE: func(ctx context.Context, itemID int64, language string) error {
+			_, err := service.GetSetByID(ctx, itemID, language)
+			return err
+		},
+	}
+
+	for _, request := range requests {
+		getItemFunc, found := getItemFuncs[request.itemType]
+		if !found {
+			continue
+		}
+
+		if err := getItemFunc(WithGame(ctx, request.game), request.itemID, request.language); err != nil {
+			log.Warn().Err(err).
+				Str(constants.LogItemType, request.itemType.String()).
+				Int64(constants.LogAnkamaID, request.itemID).
+				Msgf("Cannot prewarm item, continuing without it...")
+		}
+	}
+}
+
+func (service *Impl) RecordItemRequest(ctx context.Context) {
+	tracker, ok := ctx.Value(sourceTrackerKey{}).(*sourceTracker)
+	if !ok {
+		return
+	}
+
+	tracker.mutex.Lock()
+	requested := tracker.requested
+	tracker.mutex.Unlock()
+	if requested == nil {
+		return
+	}
+
+	service.popularityMutex.Lock()
+	defer service.popularityMutex.Unlock()
+	service.requestCounts[*requested]++
+}
+
+// trackItemRequest keeps the first item looked up within a tracked context,
+// which is the requested one: ingredients or set equipments are retrieved afterwards.
+func trackItemRequest(ctx context.Context, itemType amqp.ItemType, itemID int64, language string) {
+	tracker, ok := ctx.Value(sourceTrackerKey{}).(*sourceTracker)
+	if !ok {
+		return
+	}
+
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+	if tracker.requested == nil {
+		tracker.requested = &itemRequest{
+			game:     GetGame(ctx),
+			itemType: itemType,
+			itemID:   itemID,
+			language: language,
+		}
+	}
+}
+
+func (service *Impl) getPopularItems(game amqp.Game) []itemRequest {
+	service.popularityMutex.Lock()
+	defer service.popularityMutex.Unlock()
+
+	popularItems := make([]itemRequest, 0)
+	for _, request := range service.popularItems {
+		if request.game == game {
+			popularItems = append(popularItems, request)
+		}
+	}
+
+	return popularItems
+}
@@ -0,0 +1,35 @@
+package sources
+
+import (
+	"context"
+	"testing"
+
+	amqp "github.com/kaellybot/kaelly-amqp"
+)
+
+func TestRecordItemRequest(t *testing.T) {
+	service := &Impl{requestCounts: make(map[itemRequest]int)}
+
+	ctx := WithSourceTracking(context.Background())
+	trackItemRequest(ctx, amqp.ItemType_SET_TYPE, 1, "fr")
+	trackItemRequest(ctx, amqp.ItemType_EQUIPMENT_TYPE, 2, "fr")
+	service.RecordItemRequest(ctx)
+
+	// Lookups outside of a tracked context, such as prewarming, are not counted.
+	trackItemRequest(context.Background(), amqp.ItemType_EQUIPMENT_TYPE, 2, "fr")
+	service.RecordItemRequest(context.Background())
+
+	if len(service.requestCounts) != 1 {
+		t.Fatalf("expected a single item to be counted, got %v", service.requestCounts)
+	}
+
+	count := service.requestCounts[itemRequest{
+		game:     GetGame(ctx),
+		itemType: amqp.ItemType_SET_TYPE,
+		itemID:   1,
+		language: "fr",
+	}]
+	if count != 1 {
+		t.Errorf("expected the first looked up item to be counted once, got %v", count)
+	}
+}
@@ -0,0 +1,201 @@
+package sources
+
+import (
+	"context"
+	"time"
+
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+	"github.com/rs/zerolog/log"
+)
+
+// WithSourceTracking returns a context in which the first provider serving data is recorded,
+// so that answers can name the source they really come from.
+func WithSourceTracking(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sourceTrackerKey{}, &sourceTracker{})
+}
+
+// WithGame returns a context in which sources retrieve data of the given game;
+// an unset game stands for the default one.
+func WithGame(ctx context.Context, game amqp.Game) context.Context {
+	var unsetGame amqp.Game
+	if game == unsetGame {
+		game = constants.DefaultGame
+	}
+
+	return context.WithValue(ctx, gameKey{}, game)
+}
+
+// GetGame returns the game bound to the context, the default one if there is none.
+func GetGame(ctx context.Context) amqp.Game {
+	game, ok := ctx.Value(gameKey{}).(amqp.Game)
+	if !ok {
+		return constants.DefaultGame
+	}
+
+	return game
+}
+
+func getDofusDudeGame(ctx context.Context) string {
+	dofusDudeGame, found := constants.GetDofusDudeGames()[GetGame(ctx)]
+	if !found {
+		return constants.DofusDudeGame
+	}
+
+	return dofusDudeGame
+}
+
+// GetServingSource returns the source which served data within a tracked context,
+// flagged as stale if any served data was; DofusDude is returned if nothing has been served yet.
+func GetServingSource(ctx context.Context) constants.Source {
+	tracker, ok := ctx.Value(sourceTrackerKey{}).(*sourceTracker)
+	if !ok {
+		return constants.GetDofusDudeSource()
+	}
+
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+	source := constants.GetDofusDudeSource()
+	if tracker.source != nil {
+		source = *tracker.source
+	}
+	source.Stale = tracker.stale
+
+	return source
+}
+
+// GetStatuses returns the status of every provider used by at least one chain, by source name.
+func (service *Impl) GetStatuses() map[string]string {
+	statuses := make(map[string]string)
+	for _, chain := range service.providers {
+		for _, provider := range chain {
+			statuses[provider.GetSource().Name] = provider.GetStatus()
+		}
+	}
+
+	return statuses
+}
+
+func trackSource(ctx context.Context, source constants.Source, stale bool) {
+	tracker, ok := ctx.Value(sourceTrackerKey{}).(*sourceTracker)
+	if !ok {
+		return
+	}
+
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+	if tracker.source == nil {
+		tracker.source = &source
+	}
+	tracker.stale = tracker.stale || stale
+}
+
+// fetch goes through the provider chain of an object type: for each provider,
+// the cache is checked first, then the upstream is called. The next provider is
+// only tried if the previous one failed.
+func fetch[T any](ctx context.Context, service *Impl, objType objectType,
+	buildKey func(source string) string, call func(ctx context.Context, provider Provider) (T, error),
+) (T, constants.Source, error) {
+	var value T
+	lastErr := ErrUnsupportedGame
+	for _, provider := range service.providers[objType] {
+		if !provider.IsGameSupported(GetGame(ctx)) {
+			continue
+		}
+
+		source := provider.GetSource()
+		key := buildKey(source.Name)
+		found, stale := service.getElementFromCache(ctx, key, &value)
+		if found {
+			if stale {
+				go refresh(ctx, service, objType, key, provider, call)
+			}
+			trackSource(ctx, source, stale)
+			return value, source, nil
+		}
+
+		resp, err := coalesce(ctx, service, objType, key, provider, call)
+		if err != nil {
+			log.Warn().Err(err).
+				Str(constants.LogSource, source.Name).
+				Str(constants.LogKey, key).
+				Msgf("Error while retrieving element from source, trying next one...")
+			lastErr = err
+			continue
+		}
+
+		trackSource(ctx, source, false)
+		return resp, source, nil
+	}
+
+	return value, constants.Source{}, lastErr
+}
+
+// fetchUncached goes through the provider chain of an object type without any cache,
+// the next provider is only tried if the previous one failed.
+func fetchUncached[T any](ctx context.Context, service *Impl, objType objectType, timeout time.Duration,
+	call func(ctx context.Context, provider Provider) (T, error)) (T, error) {
+	var value T
+	lastErr := ErrUnsupportedGame
+	for _, provider := range service.providers[objType] {
+		if !provider.IsGameSupported(GetGame(ctx)) {
+			continue
+		}
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+		resp, err := call(timeoutCtx, provider)
+		cancel()
+		if err != nil {
+			log.Warn().Err(err).
+				Str(constants.LogSource, provider.GetSource().Name).
+				Msgf("Error while retrieving %v from source, trying next one...", objType)
+			lastErr = err
+			continue
+		}
+
+		return resp, nil
+	}
+
+	return value, lastErr
+}
+
+// refresh updates a stale cache entry; the stale copy is kept if the upstream call fails.
+func refresh[T any](ctx context.Context, service *Impl, objType objectType, key string, provider Provider,
+	call func(ctx context.Context, provider Provider) (T, error)) {
+	_, err := coalesce(ctx, service, objType, key, provider, call)
+	if err != nil {
+		log.Warn().Err(err).
+			Str(constants.LogSource, provider.GetSource().Name).
+			Str(constants.LogKey, key).
+			Msgf("Cannot refresh stale element, keeping it until its hard expiry...")
+	}
+}
+
+// coalesce shares one upstream call and one cache write between concurrent callers
+// looking for the same key. The shared call is not canceled if its first caller gives up.
+func coalesce[T any](ctx context.Context, service *Impl, objType objectType, key string, provider Provider,
+	call func(ctx context.Context, provider Provider) (T, error)) (T, error) {
+	value, err, shared := service.inFlight.Do(service.scopeKey(ctx, key), func() (any, error) {
+		sharedCtx := context.WithoutCancel(ctx)
+		resp, errCall := callProvider(sharedCtx, service, provider, call)
+		if errCall != nil {
+			return resp, errCall
+		}
+
+		service.putElementToCache(sharedCtx, objType, key, resp)
+		return resp, nil
+	})
+	if shared {
+		log.Debug().Str(constants.LogKey, key).Msgf("Upstream call shared with concurrent requests")
+	}
+
+	resp, _ := value.(T)
+	return resp, err
+}
+
+func callProvider[T any](ctx context.Context, service *Impl, provider Provider,
+	call func(ctx context.Context, provider Provider) (T, error)) (T, error) {
+	ctx, cancel := context.WithTimeout(ctx, service.httpTimeout)
+	defer cancel()
+	return call(ctx, provider)
+}
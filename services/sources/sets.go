@@ -0,0 +1,65 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dofusdude/dodugo"
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/utils/conversions"
+	"github.com/kaellybot/kaelly-encyclopedia/utils/rankings"
+)
+
+func (service *Impl) SearchSets(ctx context.Context, query,
+	language string) ([]dodugo.ListEquipmentSet, error) {
+	sets, _, err := fetch(ctx, service, set,
+		func(source string) string { return buildListKey(set, query, language, source) },
+		func(ctx context.Context, provider Provider) ([]dodugo.ListEquipmentSet, error) {
+			return provider.SearchSets(ctx, query, language)
+		})
+	return sets, err
+}
+
+func (service *Impl) GetSetByQuery(ctx context.Context, query, language string,
+) (*dodugo.EquipmentSet, error) {
+	values, err := service.SearchSets(ctx, query, language)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, ErrNotFound
+	}
+
+	best := values[rankings.BestMatch(query, values, getListSetName)]
+	resp, err := service.GetSetByID(ctx, int64(best.GetAnkamaId()), language)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (service *Impl) GetSetByID(ctx context.Context, setID int64, language string,
+) (*dodugo.EquipmentSet, error) {
+	int32SetID, errConv := conversions.Int64ToInt32(setID)
+	if errConv != nil {
+		return nil, errConv
+	}
+
+	trackItemRequest(ctx, amqp.ItemType_SET_TYPE, setID, language)
+
+	dodugoSet, _, err := fetch(ctx, service, set,
+		func(source string) string { return buildItemKey(set, fmt.Sprintf("%v", setID), language, source) },
+		func(ctx context.Context, provider Provider) (*dodugo.EquipmentSet, error) {
+			return provider.GetSetByID(ctx, int32SetID, language)
+		})
+	return dodugoSet, err
+}
+
+// Returns sets with minimal informations. No cache applied here.
+func (service *Impl) GetSets(ctx context.Context) ([]dodugo.ListEquipmentSet, error) {
+	return fetchUncached(ctx, service, set, service.httpTimeout,
+		func(ctx context.Context, provider Provider) ([]dodugo.ListEquipmentSet, error) {
+			return provider.GetSets(ctx)
+		})
+}
@@ -0,0 +1,673 @@
+package sources
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dofusdude/dodugo"
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+	"github.com/kaellybot/kaelly-encyclopedia/utils/rankings"
+	"github.com/rs/zerolog/log"
+)
+
+// newSnapshotProvider loads in memory the JSON exports stored in the given directory.
+// Expected layout is one sub-directory per language (fr, en, ...) containing the
+// snapshot files, plus a version file at the root.
+func newSnapshotProvider(directory string) (*snapshotProvider, error) {
+	provider := snapshotProvider{
+		catalogs: make(map[string]*snapshotCatalog),
+	}
+
+	version, errVersion := loadSnapshotVersion(directory)
+	if errVersion != nil {
+		return nil, errVersion
+	}
+	provider.version = version
+
+	for _, language := range constants.GetLanguages() {
+		if _, found := provider.catalogs[language]; found {
+			continue
+		}
+
+		catalog, err := loadSnapshotCatalog(filepath.Join(directory, language))
+		if err != nil {
+			return nil, err
+		}
+
+		provider.catalogs[language] = catalog
+	}
+
+	log.Info().
+		Str(constants.LogFileName, directory).
+		Int(constants.LogEntityCount, len(provider.catalogs)).
+		Msgf("Snapshot languages loaded")
+
+	return &provider, nil
+}
+
+func loadSnapshotVersion(directory string) (string, error) {
+	var version dodugo.Version
+	content, err := os.ReadFile(filepath.Join(directory, snapshotVersionFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			log.Warn().
+				Str(constants.LogFileName, snapshotVersionFile).
+				Msgf("Snapshot has no version file, continuing without it...")
+			return "", nil
+		}
+		return "", err
+	}
+
+	if errJSON := json.Unmarshal(content, &version); errJSON != nil {
+		return "", errJSON
+	}
+
+	return version.GetVersion(), nil
+}
+
+func loadSnapshotCatalog(directory string) (*snapshotCatalog, error) {
+	catalog := snapshotCatalog{
+		almanaxes: make(map[string]dodugo.Almanax),
+	}
+
+	var err error
+	if catalog.equipments, err = loadSnapshotFile(directory, snapshotEquipmentFile,
+		func(item dodugo.Weapon) int32 { return item.GetAnkamaId() }); err != nil {
+		return nil, err
+	}
+	if catalog.cosmetics, err = loadSnapshotFile(directory, snapshotCosmeticFile,
+		func(item dodugo.Weapon) int32 { return item.GetAnkamaId() }); err != nil {
+		return nil, err
+	}
+	if catalog.consumables, err = loadSnapshotFile(directory, snapshotConsumableFile,
+		func(item dodugo.Resource) int32 { return item.GetAnkamaId() }); err != nil {
+		return nil, err
+	}
+	if catalog.questItems, err = loadSnapshotFile(directory, snapshotQuestItemFile,
+		func(item dodugo.Resource) int32 { return item.GetAnkamaId() }); err != nil {
+		return nil, err
+	}
+	if catalog.resources, err = loadSnapshotFile(directory, snapshotResourceFile,
+		func(item dodugo.Resource) int32 { return item.GetAnkamaId() }); err != nil {
+		return nil, err
+	}
+	if catalog.mounts, err = loadSnapshotFile(directory, snapshotMountFile,
+		func(item dodugo.Mount) int32 { return item.GetAnkamaId() }); err != nil {
+		return nil, err
+	}
+	if catalog.sets, err = loadSnapshotFile(directory, snapshotSetFile,
+		func(item dodugo.EquipmentSet) int32 { return item.GetAnkamaId() }); err != nil {
+		return nil, err
+	}
+
+	almanaxes, errAlmanax := readSnapshotFile[dodugo.Almanax](directory, snapshotAlmanaxFile)
+	if errAlmanax != nil {
+		return nil, errAlmanax
+	}
+	for _, almanax := range almanaxes {
+		catalog.almanaxes[almanax.GetDate()] = almanax
+	}
+
+	catalog.almanaxBonuses, err = readSnapshotFile[dodugo.GetMetaAlmanaxBonuses200ResponseInner](
+		directory, snapshotAlmanaxBonusFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &catalog, nil
+}
+
+func loadSnapshotFile[T any](directory, fileName string, getID func(T) int32) (map[int32]T, error) {
+	values, err := readSnapshotFile[T](directory, fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[int32]T, len(values))
+	for _, value := range values {
+		result[getID(value)] = value
+	}
+
+	return result, nil
+}
+
+func readSnapshotFile[T any](directory, fileName string) ([]T, error) {
+	path := filepath.Join(directory, fileName)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			log.Warn().
+				Str(constants.LogFileName, path).
+				Msgf("Snapshot file is missing, continuing without it...")
+			return make([]T, 0), nil
+		}
+		return nil, err
+	}
+
+	var values []T
+	if errJSON := json.Unmarshal(content, &values); errJSON != nil {
+		return nil, errJSON
+	}
+
+	log.Debug().
+		Str(constants.LogFileName, path).
+		Int(constants.LogEntityCount, len(values)).
+		Msgf("Snapshot file loaded")
+
+	return values, nil
+}
+
+func (provider *snapshotProvider) GetSource() constants.Source {
+	return constants.GetSnapshotSource()
+}
+
+// IsGameSupported is only true for the default game, DofusDude exports being Dofus 3 data.
+func (provider *snapshotProvider) IsGameSupported(game amqp.Game) bool {
+	return game == constants.DefaultGame
+}
+
+func (provider *snapshotProvider) GetStatus() string {
+	return fmt.Sprintf("loaded (version %v)", provider.version)
+}
+
+func (provider *snapshotProvider) getCatalog(language string) (*snapshotCatalog, error) {
+	catalog, found := provider.catalogs[language]
+	if !found {
+		return nil, ErrNotFound
+	}
+
+	return catalog, nil
+}
+
+func (provider *snapshotProvider) SearchAnyItems(_ context.Context, query, language string,
+) ([]dodugo.GameSearch, error) {
+	catalog, err := provider.getCatalog(language)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]dodugo.GameSearch, 0)
+	searchIndexes := map[string]map[int32]dodugo.Weapon{
+		"items-equipment": catalog.equipments,
+		"items-cosmetics": catalog.cosmetics,
+	}
+	for searchIndex, items := range searchIndexes {
+		for _, item := range searchSnapshot(items, query, getWeaponName) {
+			results = append(results, newGameSearch(item.AnkamaId, item.Name, searchIndex, item.Level, item.ImageUrls))
+		}
+	}
+
+	for _, mount := range searchSnapshot(catalog.mounts, query, getMountName) {
+		results = append(results, newGameSearch(mount.AnkamaId, mount.Name, "mounts", nil, mount.ImageUrls))
+	}
+
+	resourceIndexes := map[string]map[int32]dodugo.Resource{
+		"items-consumables": catalog.consumables,
+		"items-quest_items": catalog.questItems,
+		"items-resources":   catalog.resources,
+	}
+	for searchIndex, items := range resourceIndexes {
+		for _, item := range searchSnapshot(items, query, getResourceName) {
+			results = append(results, newGameSearch(item.AnkamaId, item.Name, searchIndex, item.Level, item.ImageUrls))
+		}
+	}
+
+	slices.SortFunc(results, func(a, b dodugo.GameSearch) int {
+		return cmp.Or(cmp.Compare(a.GetName(), b.GetName()), cmp.Compare(a.GetAnkamaId(), b.GetAnkamaId()))
+	})
+	rankings.SortByMatch(query, results, getGameSearchName)
+	return limitSnapshotResults(results), nil
+}
+
+func (provider *snapshotProvider) SearchConsumables(_ context.Context, query, language string,
+) ([]dodugo.ListItem, error) {
+	catalog, err := provider.getCatalog(language)
+	if err != nil {
+		return nil, err
+	}
+
+	return searchSnapshotResources(catalog.consumables, query), nil
+}
+
+func (provider *snapshotProvider) SearchCosmetics(_ context.Context, query, language string,
+) ([]dodugo.ListItem, error) {
+	catalog, err := provider.getCatalog(language)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]dodugo.ListItem, 0)
+	for _, item := range searchSnapshot(catalog.cosmetics, query, getWeaponName) {
+		results = append(results, newListItem(item))
+	}
+
+	return limitSnapshotResults(results), nil
+}
+
+func (provider *snapshotProvider) SearchEquipments(_ context.Context, query, language string,
+) ([]dodugo.ListItem, error) {
+	catalog, err := provider.getCatalog(language)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]dodugo.ListItem, 0)
+	for _, item := range searchSnapshot(catalog.equipments, query, getWeaponName) {
+		results = append(results, newListItem(item))
+	}
+
+	return limitSnapshotResults(results), nil
+}
+
+func (provider *snapshotProvider) SearchMounts(_ context.Context, query, language string,
+) ([]dodugo.Mount, error) {
+	catalog, err := provider.getCatalog(language)
+	if err != nil {
+		return nil, err
+	}
+
+	mounts := searchSnapshot(catalog.mounts, query, getMountName)
+	return limitSnapshotResults(mounts), nil
+}
+
+func (provider *snapshotProvider) SearchQuestItems(_ context.Context, query, language string,
+) ([]dodugo.ListItem, error) {
+	catalog, err := provider.getCatalog(language)
+	if err != nil {
+		return nil, err
+	}
+
+	return searchSnapshotResources(catalog.questItems, query), nil
+}
+
+func (provider *snapshotProvider) SearchResources(_ context.Context, query, language string,
+) ([]dodugo.ListItem, error) {
+	catalog, err := provider.getCatalog(language)
+	if err != nil {
+		return nil, err
+	}
+
+	return searchSnapshotResources(catalog.resources, query), nil
+}
+
+func (provider *snapshotProvider) SearchSets(_ context.Context, query, language string,
+) ([]dodugo.ListEquipmentSet, error) {
+	catalog, err := provider.getCatalog(language)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]dodugo.ListEquipmentSet, 0)
+	for _, set := range searchSnapshot(catalog.sets, query, getSetName) {
+		results = append(results, newListEquipmentSet(set))
+	}
+
+	return limitSnapshotResults(results), nil
+}
+
+func (provider *snapshotProvider) SearchAlmanaxEffects(_ context.Context, query, language string,
+) ([]dodugo.GetMetaAlmanaxBonuses200ResponseInner, error) {
+	catalog, err := provider.getCatalog(language)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]dodugo.GetMetaAlmanaxBonuses200ResponseInner, 0)
+	normalizedQuery := rankings.Normalize(query)
+	for _, bonus := range catalog.almanaxBonuses {
+		if strings.Contains(rankings.Normalize(bonus.GetName()), normalizedQuery) {
+			results = append(results, bonus)
+		}
+	}
+
+	rankings.SortByMatch(query, results, getAlmanaxBonusName)
+	return limitSnapshotResults(results), nil
+}
+
+func (provider *snapshotProvider) ListCatalog(_ context.Context, language string,
+) (*Catalog, error) {
+	catalog, err := provider.getCatalog(language)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Catalog{
+		Consumables: newResourceListItems(catalog.consumables),
+		Cosmetics:   newListItems(catalog.cosmetics),
+		Equipments:  newListItems(catalog.equipments),
+		Mounts:      slices.Collect(maps.Values(catalog.mounts)),
+		QuestItems:  newResourceListItems(catalog.questItems),
+		Resources:   newResourceListItems(catalog.resources),
+	}, nil
+}
+
+func (provider *snapshotProvider) ListSets(_ context.Context, language string,
+) ([]dodugo.ListEquipmentSet, error) {
+	catalog, err := provider.getCatalog(language)
+	if err != nil {
+		return nil, err
+	}
+
+	sets := make([]dodugo.ListEquipmentSet, 0, len(catalog.sets))
+	for _, set := range catalog.sets {
+		sets = append(sets, newListEquipmentSet(set))
+	}
+
+	return sets, nil
+}
+
+func (provider *snapshotProvider) ListAlmanaxEffects(_ context.Context, language string,
+) ([]dodugo.GetMetaAlmanaxBonuses200ResponseInner, error) {
+	catalog, err := provider.getCatalog(language)
+	if err != nil {
+		return nil, err
+	}
+
+	return catalog.almanaxBonuses, nil
+}
+
+// FilterEquipments ignores the type name: snapshot items only hold translated type names,
+// equipments are filtered by type by the caller.
+func (provider *snapshotProvider) FilterEquipments(_ context.Context, _ string, minLevel, maxLevel int32,
+	language string) ([]dodugo.ListItem, error) {
+	catalog, err := provider.getCatalog(language)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dodugo.ListItem, 0)
+	for _, item := range catalog.equipments {
+		if (minLevel > 0 && item.GetLevel() < minLevel) || (maxLevel > 0 && item.GetLevel() > maxLevel) {
+			continue
+		}
+		items = append(items, newListItem(item))
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].GetLevel() < items[j].GetLevel()
+	})
+
+	return items, nil
+}
+
+func (provider *snapshotProvider) GetConsumableByID(_ context.Context, itemID int32, language string,
+) (*dodugo.Resource, error) {
+	catalog, err := provider.getCatalog(language)
+	if err != nil {
+		return nil, err
+	}
+
+	return getSnapshotValue(catalog.consumables, itemID), nil
+}
+
+func (provider *snapshotProvider) GetCosmeticByID(_ context.Context, itemID int32, language string,
+) (*dodugo.Weapon, error) {
+	catalog, err := provider.getCatalog(language)
+	if err != nil {
+		return nil, err
+	}
+
+	return getSnapshotValue(catalog.cosmetics, itemID), nil
+}
+
+func (provider *snapshotProvider) GetEquipmentByID(_ context.Context, itemID int32, language string,
+) (*dodugo.Weapon, error) {
+	catalog, err := provider.getCatalog(language)
+	if err != nil {
+		return nil, err
+	}
+
+	return getSnapshotValue(catalog.equipments, itemID), nil
+}
+
+func (provider *snapshotProvider) GetMountByID(_ context.Context, itemID int32, language string,
+) (*dodugo.Mount, error) {
+	catalog, err := provider.getCatalog(language)
+	if err != nil {
+		return nil, err
+	}
+
+	return getSnapshotValue(catalog.mounts, itemID), nil
+}
+
+func (provider *snapshotProvider) GetQuestItemByID(_ context.Context, itemID int32, language string,
+) (*dodugo.Resource, error) {
+	catalog, err := provider.getCatalog(language)
+	if err != nil {
+		return nil, err
+	}
+
+	return getSnapshotValue(catalog.questItems, itemID), nil
+}
+
+func (provider *snapshotProvider) GetResourceByID(_ context.Context, itemID int32, language string,
+) (*dodugo.Resource, error) {
+	catalog, err := provider.getCatalog(language)
+	if err != nil {
+		return nil, err
+	}
+
+	return getSnapshotValue(catalog.resources, itemID), nil
+}
+
+func (provider *snapshotProvider) GetSetByID(_ context.Context, setID int32, language string,
+) (*dodugo.EquipmentSet, error) {
+	catalog, err := provider.getCatalog(language)
+	if err != nil {
+		return nil, err
+	}
+
+	return getSnapshotValue(catalog.sets, setID), nil
+}
+
+func (provider *snapshotProvider) GetSets(_ context.Context) ([]dodugo.ListEquipmentSet, error) {
+	catalog, err := provider.getCatalog(constants.DofusDudeDefaultLanguage)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]dodugo.ListEquipmentSet, 0, len(catalog.sets))
+	for _, set := range catalog.sets {
+		results = append(results, newListEquipmentSet(set))
+	}
+
+	return results, nil
+}
+
+func (provider *snapshotProvider) GetAlmanaxByDate(_ context.Context, date, language string,
+) (*dodugo.Almanax, error) {
+	catalog, err := provider.getCatalog(language)
+	if err != nil {
+		return nil, err
+	}
+
+	return getSnapshotValue(catalog.almanaxes, date), nil
+}
+
+func (provider *snapshotProvider) GetAlmanaxByRange(_ context.Context, daysDuration int32, language string,
+) ([]dodugo.Almanax, error) {
+	catalog, err := provider.getCatalog(language)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]dodugo.Almanax, 0)
+	today := time.Now().UTC()
+	for i := range int(daysDuration) {
+		date := today.AddDate(0, 0, i).Format(constants.DofusDudeAlmanaxDateFormat)
+		almanax, found := catalog.almanaxes[date]
+		if !found {
+			return nil, ErrNotFound
+		}
+
+		results = append(results, almanax)
+	}
+
+	return results, nil
+}
+
+func (provider *snapshotProvider) GetGameVersion(_ context.Context) (string, error) {
+	if provider.version == "" {
+		return "", ErrNotFound
+	}
+
+	return provider.version, nil
+}
+
+func getSnapshotValue[K comparable, T any](values map[K]T, id K) *T {
+	value, found := values[id]
+	if !found {
+		return nil
+	}
+
+	return &value
+}
+
+// searchSnapshot returns values containing the query, ranked like DofusDude results.
+// Values are sorted by name first so that equally ranked values keep a stable order.
+func searchSnapshot[T any](values map[int32]T, query string, getName func(T) string) []T {
+	results := make([]T, 0)
+	normalizedQuery := rankings.Normalize(query)
+	for _, id := range slices.Sorted(maps.Keys(values)) {
+		if strings.Contains(rankings.Normalize(getName(values[id])), normalizedQuery) {
+			results = append(results, values[id])
+		}
+	}
+
+	// Results are in ID order beforehand, so that ties do not depend on map iteration order.
+	slices.SortStableFunc(results, func(a, b T) int { return cmp.Compare(getName(a), getName(b)) })
+	rankings.SortByMatch(query, results, getName)
+	return results
+}
+
+func limitSnapshotResults[T any](values []T) []T {
+	if len(values) > constants.DofusDudeLimit {
+		return values[:constants.DofusDudeLimit]
+	}
+
+	return values
+}
+
+// newGameSearch builds a search result with item fields, level is nil for mounts.
+func newGameSearch(ankamaID *int32, name *string, searchIndex string, level *int32,
+	imageURLs *dodugo.Images) dodugo.GameSearch {
+	searchType := dodugo.GameSearchType{NameId: &searchIndex}
+	return dodugo.GameSearch{
+		AnkamaId: ankamaID,
+		Name:     name,
+		Type:     &searchType,
+		ItemFields: &dodugo.GameSearchItem{
+			Type:      &searchType,
+			Level:     *dodugo.NewNullableInt32(level),
+			ImageUrls: imageURLs,
+		},
+	}
+}
+
+func newListItems(items map[int32]dodugo.Weapon) []dodugo.ListItem {
+	listItems := make([]dodugo.ListItem, 0, len(items))
+	for _, item := range items {
+		listItems = append(listItems, newListItem(item))
+	}
+
+	return listItems
+}
+
+func newResourceListItems(items map[int32]dodugo.Resource) []dodugo.ListItem {
+	listItems := make([]dodugo.ListItem, 0, len(items))
+	for _, item := range items {
+		listItems = append(listItems, newResourceListItem(item))
+	}
+
+	return listItems
+}
+
+func newListItem(item dodugo.Weapon) dodugo.ListItem {
+	return dodugo.ListItem{
+		AnkamaId:   item.AnkamaId,
+		Name:       item.Name,
+		Type:       item.Type,
+		Level:      item.Level,
+		ImageUrls:  item.ImageUrls,
+		Recipe:     item.Recipe,
+		Conditions: item.Conditions,
+		Effects:    item.Effects,
+	}
+}
+
+func newResourceListItem(item dodugo.Resource) dodugo.ListItem {
+	return dodugo.ListItem{
+		AnkamaId:   item.AnkamaId,
+		Name:       item.Name,
+		Type:       item.Type,
+		Level:      item.Level,
+		ImageUrls:  item.ImageUrls,
+		Recipe:     item.Recipe,
+		Conditions: item.Conditions,
+		Effects:    item.Effects,
+	}
+}
+
+func searchSnapshotResources(items map[int32]dodugo.Resource, query string) []dodugo.ListItem {
+	results := make([]dodugo.ListItem, 0)
+	for _, item := range searchSnapshot(items, query, getResourceName) {
+		results = append(results, newResourceListItem(item))
+	}
+
+	return limitSnapshotResults(results)
+}
+
+func newListEquipmentSet(set dodugo.EquipmentSet) dodugo.ListEquipmentSet {
+	return dodugo.ListEquipmentSet{
+		AnkamaId:              set.AnkamaId,
+		Name:                  set.Name,
+		Level:                 set.HighestEquipmentLevel,
+		Effects:               set.Effects,
+		EquipmentIds:          set.EquipmentIds,
+		ContainsCosmetics:     set.ContainsCosmetics,
+		ContainsCosmeticsOnly: set.ContainsCosmeticsOnly,
+	}
+}
+
+func getWeaponName(item dodugo.Weapon) string {
+	return item.GetName()
+}
+
+func getResourceName(item dodugo.Resource) string {
+	return item.GetName()
+}
+
+func getMountName(mount dodugo.Mount) string {
+	return mount.GetName()
+}
+
+func getSetName(set dodugo.EquipmentSet) string {
+	return set.GetName()
+}
+
+func getListItemName(item dodugo.ListItem) string {
+	return item.GetName()
+}
+
+func getListSetName(set dodugo.ListEquipmentSet) string {
+	return set.GetName()
+}
+
+func getGameSearchName(item dodugo.GameSearch) string {
+	return item.GetName()
+}
+
+func getAlmanaxBonusName(bonus dodugo.GetMetaAlmanaxBonuses200ResponseInner) string {
+	return bonus.GetName()
+}
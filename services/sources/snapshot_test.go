@@ -0,0 +1,190 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func writeSnapshotFile(t *testing.T, directory, fileName, content string) {
+	t.Helper()
+	if err := os.MkdirAll(directory, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(directory, fileName), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newTestSnapshotProvider(t *testing.T) *snapshotProvider {
+	t.Helper()
+	directory := t.TempDir()
+	writeSnapshotFile(t, directory, snapshotVersionFile, `{"version": "3.0.1"}`)
+	writeSnapshotFile(t, filepath.Join(directory, "fr"), snapshotEquipmentFile, `[
+		{"ankama_id": 1, "name": "Anneau Gelé", "level": 120},
+		{"ankama_id": 2, "name": "Gelano", "level": 60},
+		{"ankama_id": 3, "name": "Gelanneau", "level": 80},
+		{"ankama_id": 4, "name": "Coiffe du Bouftou", "level": 10}
+	]`)
+	writeSnapshotFile(t, filepath.Join(directory, "fr"), snapshotResourceFile, `[
+		{"ankama_id": 10, "name": "Laine de Bouftou", "level": 1}
+	]`)
+	writeSnapshotFile(t, filepath.Join(directory, "fr"), snapshotAlmanaxFile, `[
+		{"date": "2026-12-01", "tribute": {"item": {"ankama_id": 10, "name": "Laine de Bouftou"}, "quantity": 3}}
+	]`)
+
+	provider, err := newSnapshotProvider(directory)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return provider
+}
+
+func TestSnapshotProviderLoading(t *testing.T) {
+	provider := newTestSnapshotProvider(t)
+
+	version, err := provider.GetGameVersion(context.Background())
+	if err != nil || version != "3.0.1" {
+		t.Fatalf("expected version 3.0.1, got %v (%v)", version, err)
+	}
+
+	equipment, err := provider.GetEquipmentByID(context.Background(), 2, "fr")
+	if err != nil || equipment == nil || equipment.GetName() != "Gelano" {
+		t.Fatalf("expected Gelano, got %v (%v)", equipment, err)
+	}
+
+	missing, err := provider.GetEquipmentByID(context.Background(), 404, "fr")
+	if err != nil || missing != nil {
+		t.Fatalf("expected no equipment, got %v (%v)", missing, err)
+	}
+
+	// Missing language directories and files are skipped.
+	catalog, err := provider.ListCatalog(context.Background(), "en")
+	if err != nil || len(catalog.Equipments) != 0 {
+		t.Fatalf("expected no english equipment, got %v (%v)", catalog, err)
+	}
+
+	if _, err = provider.GetEquipmentByID(context.Background(), 2, "unknown"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for an unknown language, got %v", err)
+	}
+}
+
+func TestSnapshotProviderSearchEquipments(t *testing.T) {
+	provider := newTestSnapshotProvider(t)
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []string
+	}{
+		{
+			name:     "prefix matches first, then by edit distance",
+			query:    "gela",
+			expected: []string{"Gelano", "Gelanneau"},
+		},
+		{
+			name:     "accents and case ignored",
+			query:    "anneau gele",
+			expected: []string{"Anneau Gelé"},
+		},
+		{
+			name:     "no match",
+			query:    "dofus",
+			expected: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			items, err := provider.SearchEquipments(context.Background(), test.query, "fr")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(items) != len(test.expected) {
+				t.Fatalf("expected %v results, got %v", len(test.expected), len(items))
+			}
+			for i, item := range items {
+				if item.GetName() != test.expected[i] {
+					t.Errorf("expected %v at position %v, got %v", test.expected[i], i, item.GetName())
+				}
+			}
+		})
+	}
+}
+
+func TestSnapshotProviderSearchAnyItems(t *testing.T) {
+	provider := newTestSnapshotProvider(t)
+
+	items, err := provider.SearchAnyItems(context.Background(), "bouftou", "fr")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 2 {
+		t.Fatalf("expected 2 results, got %v", len(items))
+	}
+
+	searchIndexes := map[string]string{}
+	for _, item := range items {
+		searchType := item.GetType()
+		searchIndexes[item.GetName()] = searchType.GetNameId()
+	}
+	if searchIndexes["Coiffe du Bouftou"] != "items-equipment" ||
+		searchIndexes["Laine de Bouftou"] != "items-resources" {
+		t.Errorf("unexpected search indexes: %v", searchIndexes)
+	}
+}
+
+func TestSnapshotProviderSearchAnyItemsTies(t *testing.T) {
+	directory := t.TempDir()
+	writeSnapshotFile(t, directory, snapshotVersionFile, `{"version": "3.0.1"}`)
+	writeSnapshotFile(t, filepath.Join(directory, "fr"), snapshotEquipmentFile, `[
+		{"ankama_id": 7, "name": "Bouftou"},
+		{"ankama_id": 5, "name": "Bouftou"}
+	]`)
+	writeSnapshotFile(t, filepath.Join(directory, "fr"), snapshotResourceFile, `[
+		{"ankama_id": 6, "name": "Bouftou"}
+	]`)
+	provider, err := newSnapshotProvider(directory)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Map iteration order changes from one call to another.
+	for range 20 {
+		items, errSearch := provider.SearchAnyItems(context.Background(), "bouftou", "fr")
+		if errSearch != nil {
+			t.Fatal(errSearch)
+		}
+
+		ids := make([]int32, 0, len(items))
+		for _, item := range items {
+			ids = append(ids, item.GetAnkamaId())
+		}
+		if !slices.Equal(ids, []int32{5, 6, 7}) {
+			t.Fatalf("expected ties ordered by ID, got %v", ids)
+		}
+	}
+}
+
+func TestSnapshotProviderGetAlmanaxByDate(t *testing.T) {
+	provider := newTestSnapshotProvider(t)
+
+	almanax, err := provider.GetAlmanaxByDate(context.Background(), "2026-12-01", "fr")
+	if err != nil || almanax == nil {
+		t.Fatalf("expected an almanax, got %v (%v)", almanax, err)
+	}
+	if almanax.Tribute.GetQuantity() != 3 {
+		t.Errorf("expected a quantity of 3, got %v", almanax.Tribute.GetQuantity())
+	}
+
+	missing, err := provider.GetAlmanaxByDate(context.Background(), "2026-12-02", "fr")
+	if err != nil || missing != nil {
+		t.Fatalf("expected no almanax, got %v (%v)", missing, err)
+	}
+}
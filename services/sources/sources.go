@@ -1,42 +1,109 @@
 package sources
 
 import (
-	"github.com/dofusdude/dodugo"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/go-co-op/gocron/v2"
 	amqp "github.com/kaellybot/kaelly-amqp"
 	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
 	"github.com/kaellybot/kaelly-encyclopedia/repositories/games"
 	"github.com/kaellybot/kaelly-encyclopedia/services/stores"
+	"github.com/rs/zerolog/log"
 	"github.com/spf13/viper"
 )
 
 func New(scheduler gocron.Scheduler, storeService stores.Service,
 	gameRepo games.Repository) (*Impl, error) {
-	config := dodugo.NewConfiguration()
-	config.UserAgent = constants.UserAgent
-	apiClient := dodugo.NewAPIClient(config)
+	registeredProviders := make(map[string]Provider)
+	for _, provider := range []Provider{newDofusDudeProvider()} {
+		registeredProviders[provider.GetSource().Name] = provider
+	}
+
+	if snapshotDirectory := viper.GetString(constants.SnapshotDirectory); snapshotDirectory != "" {
+		snapshot, errSnapshot := newSnapshotProvider(snapshotDirectory)
+		if errSnapshot != nil {
+			return nil, errSnapshot
+		}
+		registeredProviders[snapshot.GetSource().Name] = snapshot
+	}
+
+	providers, errProviders := buildProviderChains(registeredProviders)
+	if errProviders != nil {
+		return nil, errProviders
+	}
 
 	service := Impl{
 		eventHandlers:   make([]GameEventHandler, 0),
-		dofusDudeClient: apiClient,
+		catalogHandlers: make([]CatalogHandler, 0),
+		providers:       providers,
 		storeService:    storeService,
 		gameRepo:        gameRepo,
 		httpTimeout:     viper.GetDuration(constants.DofusDudeTimeout),
 		itemTypes: map[string]amqp.ItemType{
-			"consumables":     amqp.ItemType_CONSUMABLE_TYPE,
-			"equipment":       amqp.ItemType_EQUIPMENT_TYPE,
-			"items-cosmetics": amqp.ItemType_COSMETIC_TYPE,
-			"items-equipment": amqp.ItemType_EQUIPMENT_TYPE,
-			"mounts":          amqp.ItemType_MOUNT_TYPE,
-			"quest":           amqp.ItemType_QUEST_ITEM_TYPE,
-			"resources":       amqp.ItemType_RESOURCE_TYPE,
-			"sets":            amqp.ItemType_SET_TYPE,
+			"consumables":       amqp.ItemType_CONSUMABLE_TYPE,
+			"equipment":         amqp.ItemType_EQUIPMENT_TYPE,
+			"items-consumables": amqp.ItemType_CONSUMABLE_TYPE,
+			"items-cosmetics":   amqp.ItemType_COSMETIC_TYPE,
+			"items-equipment":   amqp.ItemType_EQUIPMENT_TYPE,
+			"items-quest_items": amqp.ItemType_QUEST_ITEM_TYPE,
+			"items-resources":   amqp.ItemType_RESOURCE_TYPE,
+			"mounts":            amqp.ItemType_MOUNT_TYPE,
+			"quest":             amqp.ItemType_QUEST_ITEM_TYPE,
+			"resources":         amqp.ItemType_RESOURCE_TYPE,
+			"sets":              amqp.ItemType_SET_TYPE,
+		},
+		cacheRetentions: map[objectType]time.Duration{
+			almanax:       viper.GetDuration(constants.AlmanaxCacheRetention),
+			almanaxRange:  viper.GetDuration(constants.AlmanaxRangeCacheRetention),
+			almanaxEffect: viper.GetDuration(constants.AlmanaxEffectCacheRetention),
+			item:          viper.GetDuration(constants.ItemCacheRetention),
+			set:           viper.GetDuration(constants.SetCacheRetention),
 		},
+		notFoundRetention: viper.GetDuration(constants.NotFoundCacheRetention),
+		prewarmItemCount:  viper.GetInt(constants.PrewarmItemCount),
+		requestCounts:     make(map[itemRequest]int),
+		popularItems:      make([]itemRequest, 0),
+		cacheVersions:     make(map[amqp.Game]string),
+		indexes:           make(map[amqp.Game]map[string]*languageIndex),
+		indexTimeout:      viper.GetDuration(constants.IndexTimeout),
 	}
 
+	service.ListenGameEvent(func(game amqp.Game, _ string) { service.rebuildIndexes(game) })
+
+	// Right after a game update, the new cache namespace is empty.
+	service.ListenGameEvent(func(game amqp.Game, _ string) {
+		service.prewarmItems(context.Background(), service.getPopularItems(game))
+	})
+
+	for game := range constants.GetDofusDudeGames() {
+		if gameVersion, errVersion := gameRepo.GetGameVersion(game); errVersion == nil {
+			service.cacheVersions[game] = gameVersion.Version
+		} else {
+			log.Warn().Err(errVersion).
+				Msgf("Cannot retrieve %v version from DB, cache is not scoped until next check", game)
+		}
+	}
+
+	// Indexes are built once the scheduler starts, when every catalog handler is registered.
 	_, errJob := scheduler.NewJob(
+		gocron.OneTimeJob(gocron.OneTimeJobStartImmediately()),
+		gocron.NewTask(func() {
+			for game := range constants.GetDofusDudeGames() {
+				service.rebuildIndexes(game)
+			}
+		}),
+		gocron.WithName("Build autocomplete indexes"),
+	)
+	if errJob != nil {
+		return nil, errJob
+	}
+
+	_, errJob = scheduler.NewJob(
 		gocron.CronJob(viper.GetString(constants.UpdateSetCronTab), true),
-		gocron.NewTask(func() { service.checkGameVersion() }),
+		gocron.NewTask(func() { service.checkGameVersions() }),
 		gocron.WithName("Check game version"),
 	)
 	if errJob != nil {
@@ -45,3 +112,39 @@ func New(scheduler gocron.Scheduler, storeService stores.Service,
 
 	return &service, nil
 }
+
+func buildProviderChains(registeredProviders map[string]Provider,
+) (map[objectType][]Provider, error) {
+	configKeys := map[objectType]string{
+		almanax:       constants.AlmanaxSources,
+		almanaxRange:  constants.AlmanaxRangeSources,
+		almanaxEffect: constants.AlmanaxEffectSources,
+		item:          constants.ItemSources,
+		set:           constants.SetSources,
+	}
+
+	chains := make(map[objectType][]Provider)
+	for objType, configKey := range configKeys {
+		chain := make([]Provider, 0)
+		for _, name := range strings.Split(viper.GetString(configKey), ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+
+			provider, found := registeredProviders[name]
+			if !found {
+				return nil, fmt.Errorf("%w: '%v' used by %v", ErrUnknownProvider, name, configKey)
+			}
+			chain = append(chain, provider)
+		}
+
+		if len(chain) == 0 {
+			return nil, fmt.Errorf("%w: %v is empty", ErrNoProvider, configKey)
+		}
+
+		chains[objType] = chain
+	}
+
+	return chains, nil
+}
@@ -0,0 +1,81 @@
+package sources
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+	"github.com/spf13/viper"
+)
+
+func setSourcesConfig(t *testing.T, value string) {
+	t.Helper()
+	for _, key := range []string{constants.AlmanaxSources, constants.AlmanaxRangeSources,
+		constants.AlmanaxEffectSources, constants.ItemSources, constants.SetSources} {
+		viper.Set(key, value)
+	}
+	t.Cleanup(viper.Reset)
+}
+
+func TestBuildProviderChains(t *testing.T) {
+	dofusDude := &dofusDudeProvider{}
+	fallback := &dofusDudeProvider{}
+	registeredProviders := map[string]Provider{
+		constants.DofusDudeSourceName: dofusDude,
+		"fallback":                    fallback,
+	}
+
+	tests := []struct {
+		name     string
+		config   string
+		expected []Provider
+		err      error
+	}{
+		{
+			name:     "single provider",
+			config:   constants.DofusDudeSourceName,
+			expected: []Provider{dofusDude},
+		},
+		{
+			name:     "ordered by priority, spaces and empty names ignored",
+			config:   " fallback, ," + constants.DofusDudeSourceName,
+			expected: []Provider{fallback, dofusDude},
+		},
+		{
+			name:   "unknown provider",
+			config: "unknown",
+			err:    ErrUnknownProvider,
+		},
+		{
+			name:   "empty chain",
+			config: " , ",
+			err:    ErrNoProvider,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			setSourcesConfig(t, test.config)
+
+			chains, err := buildProviderChains(registeredProviders)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error %v, got %v", test.err, err)
+			}
+			if test.err != nil {
+				return
+			}
+
+			for _, objType := range []objectType{almanax, almanaxRange, almanaxEffect, item, set} {
+				chain := chains[objType]
+				if len(chain) != len(test.expected) {
+					t.Fatalf("%v: expected %v providers, got %v", objType, len(test.expected), len(chain))
+				}
+				for i, provider := range chain {
+					if provider != test.expected[i] {
+						t.Errorf("%v: unexpected provider at position %v", objType, i)
+					}
+				}
+			}
+		})
+	}
+}
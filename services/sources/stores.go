@@ -4,14 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
+	"slices"
 
 	"github.com/go-redis/cache/v9"
+	amqp "github.com/kaellybot/kaelly-amqp"
 	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
 	"github.com/rs/zerolog/log"
 )
 
-func (service *Impl) getElementFromCache(ctx context.Context, key string, value any) bool {
-	err := service.storeService.Get(ctx, key, value)
+// getElementFromCache reports whether the element has been found and whether it is stale.
+func (service *Impl) getElementFromCache(ctx context.Context, key string, value any) (bool, bool) {
+	stale, err := service.storeService.Get(ctx, service.scopeKey(ctx, key), value)
 	if err != nil {
 		if errors.Is(err, cache.ErrCacheMiss) {
 			log.Info().
@@ -24,11 +28,17 @@ func (service *Impl) getElementFromCache(ctx context.Context, key string, value
 		}
 	}
 
-	return err == nil
+	return err == nil, stale
 }
 
-func (service *Impl) putElementToCache(ctx context.Context, key string, value any) {
-	err := service.storeService.Set(ctx, key, value)
+func (service *Impl) putElementToCache(ctx context.Context, objType objectType, key string, value any) {
+	var err error
+	if isNotFound(value) {
+		err = service.storeService.SetNotFound(ctx, service.scopeKey(ctx, key), service.notFoundRetention)
+	} else {
+		err = service.storeService.Set(ctx, service.scopeKey(ctx, key), value, service.cacheRetentions[objType])
+	}
+
 	if err != nil {
 		log.Error().Err(err).
 			Str(constants.LogKey, key).
@@ -36,10 +46,103 @@ func (service *Impl) putElementToCache(ctx context.Context, key string, value an
 	}
 }
 
+// isNotFound reports whether a provider returned nothing: nil pointers and empty lists.
+func isNotFound(value any) bool {
+	if value == nil {
+		return true
+	}
+
+	reflected := reflect.ValueOf(value)
+	//nolint:exhaustive // Other kinds cannot be empty results.
+	switch reflected.Kind() {
+	case reflect.Pointer, reflect.Map:
+		return reflected.IsNil()
+	case reflect.Slice:
+		return reflected.Len() == 0
+	default:
+		return false
+	}
+}
+
+// scopeKey namespaces a key by the requested game and its current version,
+// so that a game update starts a fresh cache.
+func (service *Impl) scopeKey(ctx context.Context, key string) string {
+	game := GetGame(ctx)
+	gameVersion := service.getCacheVersion(game)
+	if gameVersion == "" {
+		return fmt.Sprintf("%v/%v", game, key)
+	}
+
+	return fmt.Sprintf("%v/%v/%v", game, gameVersion, key)
+}
+
+func (service *Impl) getCacheVersion(game amqp.Game) string {
+	service.cacheVersionMutex.RLock()
+	defer service.cacheVersionMutex.RUnlock()
+	return service.cacheVersions[game]
+}
+
+// switchCacheVersion starts a new cache namespace for the game and deletes the previous one.
+func (service *Impl) switchCacheVersion(ctx context.Context, game amqp.Game, gameVersion string) {
+	service.cacheVersionMutex.Lock()
+	previousVersion := service.cacheVersions[game]
+	service.cacheVersions[game] = gameVersion
+	service.cacheVersionMutex.Unlock()
+
+	if previousVersion == gameVersion {
+		return
+	}
+
+	if previousVersion == "" {
+		log.Info().Msgf("Deleting unversioned cache entries of %v...", game)
+	} else {
+		log.Info().Msgf("Deleting cache entries of %v version '%v'...", game, previousVersion)
+	}
+
+	for _, prefix := range service.getCachePrefixes(game, previousVersion) {
+		if err := service.storeService.DeleteByPrefix(ctx, prefix); err != nil {
+			log.Error().Err(err).
+				Msgf("Cannot delete cache entries of %v version '%v', letting them expire...", game, previousVersion)
+		}
+	}
+}
+
+// getCachePrefixes returns the key prefixes of a game version namespace. Without version, keys are
+// the ones stored before versioning: they are targeted source by source, since the game prefix alone
+// also matches every versioned namespace.
+func (service *Impl) getCachePrefixes(game amqp.Game, gameVersion string) []string {
+	if gameVersion != "" {
+		return []string{fmt.Sprintf("%v/%v/", game, gameVersion)}
+	}
+
+	prefixes := make([]string, 0)
+	for _, chain := range service.providers {
+		for _, provider := range chain {
+			prefix := fmt.Sprintf("%v/%v/", game, provider.GetSource().Name)
+			if !slices.Contains(prefixes, prefix) {
+				prefixes = append(prefixes, prefix)
+			}
+		}
+	}
+
+	return prefixes
+}
+
 func buildListKey(objType objectType, query, language, source string) string {
 	return fmt.Sprintf("%v/%v?query=%v&lg=%v", source, objType, query, language)
 }
 
+// buildItemListKey distinguishes item searches by type, since their results differ for a same query.
+func buildItemListKey(itemType amqp.ItemType, query, language, source string) string {
+	return fmt.Sprintf("%v/%v/%v?query=%v&lg=%v", source, item, itemType, query, language)
+}
+
+// buildEquipmentFilterKey distinguishes equipment lists by filter, since they are filtered upstream.
+func buildEquipmentFilterKey(typeName string, minLevel, maxLevel int64, language, source string) string {
+	return fmt.Sprintf("%v/%v/filter?type=%v&minLevel=%v&maxLevel=%v&lg=%v",
+		source, item, typeName, minLevel, maxLevel, language)
+}
+
 func buildItemKey(objType objectType, query, language, source string) string {
 	return fmt.Sprintf("%v/%v/%v?lg=%v", source, objType, query, language)
 }
@@ -0,0 +1,108 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/dofusdude/dodugo"
+	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+	"github.com/kaellybot/kaelly-encyclopedia/services/stores"
+)
+
+type fakeStoreService struct {
+	stores.Service
+	deletedPrefixes []string
+}
+
+func (service *fakeStoreService) DeleteByPrefix(_ context.Context, prefix string) error {
+	service.deletedPrefixes = append(service.deletedPrefixes, prefix)
+	return nil
+}
+
+func TestIsNotFound(t *testing.T) {
+	var nilAlmanax *dodugo.Almanax
+	var nilMap map[string]int
+	var nilSlice []dodugo.GameSearch
+
+	tests := []struct {
+		name     string
+		value    any
+		expected bool
+	}{
+		{name: "nil", value: nil, expected: true},
+		{name: "nil pointer", value: nilAlmanax, expected: true},
+		{name: "pointer", value: &dodugo.Almanax{}, expected: false},
+		{name: "nil map", value: nilMap, expected: true},
+		{name: "empty map", value: map[string]int{}, expected: false},
+		{name: "nil slice", value: nilSlice, expected: true},
+		{name: "empty slice", value: []dodugo.GameSearch{}, expected: true},
+		{name: "slice", value: []dodugo.GameSearch{{}}, expected: false},
+		{name: "string", value: "", expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := isNotFound(test.value); actual != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSwitchCacheVersion(t *testing.T) {
+	dofusDude := &dofusDudeProvider{}
+	snapshot := &snapshotProvider{}
+	game := amqp.Game_DOFUS_GAME
+	dofusDudePrefix := fmt.Sprintf("%v/%v/", game, constants.DofusDudeSourceName)
+	snapshotPrefix := fmt.Sprintf("%v/%v/", game, constants.GetSnapshotSource().Name)
+
+	tests := []struct {
+		name            string
+		previousVersion string
+		gameVersion     string
+		expected        []string
+	}{
+		{
+			name:        "first version deletes unversioned entries",
+			gameVersion: "1.0",
+			expected:    []string{dofusDudePrefix, snapshotPrefix},
+		},
+		{
+			name:            "new version deletes previous namespace",
+			previousVersion: "1.0",
+			gameVersion:     "1.1",
+			expected:        []string{fmt.Sprintf("%v/1.0/", game)},
+		},
+		{
+			name:            "same version deletes nothing",
+			previousVersion: "1.0",
+			gameVersion:     "1.0",
+			expected:        []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			storeService := fakeStoreService{deletedPrefixes: make([]string, 0)}
+			service := Impl{
+				storeService:  &storeService,
+				cacheVersions: map[amqp.Game]string{game: test.previousVersion},
+				providers: map[objectType][]Provider{
+					almanax: {dofusDude, snapshot},
+					item:    {dofusDude},
+				},
+			}
+
+			service.switchCacheVersion(context.Background(), game, test.gameVersion)
+			if !slices.Equal(storeService.deletedPrefixes, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, storeService.deletedPrefixes)
+			}
+			if version := service.getCacheVersion(game); version != test.gameVersion {
+				t.Errorf("expected version '%v', got '%v'", test.gameVersion, version)
+			}
+		})
+	}
+}
@@ -3,16 +3,41 @@ package sources
 import (
 	"context"
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/dofusdude/dodugo"
 	amqp "github.com/kaellybot/kaelly-amqp"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
 	"github.com/kaellybot/kaelly-encyclopedia/repositories/games"
 	"github.com/kaellybot/kaelly-encyclopedia/services/stores"
+	"github.com/kaellybot/kaelly-encyclopedia/utils/breakers"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/singleflight"
 )
 
 type objectType string
 
+const (
+	snapshotVersionFile      = "version.json"
+	snapshotEquipmentFile    = "equipment.json"
+	snapshotCosmeticFile     = "cosmetics.json"
+	snapshotConsumableFile   = "consumables.json"
+	snapshotQuestItemFile    = "quest_items.json"
+	snapshotResourceFile     = "resources.json"
+	snapshotMountFile        = "mounts.json"
+	snapshotSetFile          = "sets.json"
+	snapshotAlmanaxFile      = "almanax.json"
+	snapshotAlmanaxBonusFile = "almanax_bonuses.json"
+)
+
+const (
+	trigramSize = 3
+
+	almanaxFetchConcurrency = 7
+	almanaxDayDuration      = 24 * time.Hour
+)
+
 const (
 	almanax       objectType = "almanax"
 	almanaxRange  objectType = "almanaxRange"
@@ -22,22 +47,50 @@ const (
 )
 
 var (
-	ErrFuncNotFound = errors.New("no possibility to retrieve item")
-	ErrNotFound     = errors.New("cannot find the desired resource")
+	ErrFuncNotFound    = errors.New("no possibility to retrieve item")
+	ErrNotFound        = errors.New("cannot find the desired resource")
+	ErrUnknownProvider = errors.New("provider is not registered")
+	ErrNoProvider      = errors.New("no provider configured")
+	ErrUnsupportedGame = errors.New("game is not supported by any provider")
+	ErrInvalidRange    = errors.New("date range is reversed or exceeds the almanax size limit")
 )
 
-type GameEventHandler func(gameVersion string)
+type GameEventHandler func(game amqp.Game, gameVersion string)
+
+// CatalogHandler is called with the catalog of a game language each time autocomplete indexes retrieve it.
+type CatalogHandler func(game amqp.Game, language string, catalog *Catalog)
+
+// Catalog holds the full item lists of a language, recipes and effects included.
+// Equipments and cosmetics are restricted to supported types.
+type Catalog struct {
+	Consumables []dodugo.ListItem
+	Cosmetics   []dodugo.ListItem
+	Equipments  []dodugo.ListItem
+	Mounts      []dodugo.Mount
+	QuestItems  []dodugo.ListItem
+	Resources   []dodugo.ListItem
+}
 
 type Service interface {
 	GetItemType(itemType string) amqp.ItemType
 
 	SearchAnyItems(ctx context.Context, query, lg string) ([]dodugo.GameSearch, error)
+	SearchConsumables(ctx context.Context, query, lg string) ([]dodugo.ListItem, error)
 	SearchCosmetics(ctx context.Context, query, lg string) ([]dodugo.ListItem, error)
 	SearchEquipments(ctx context.Context, query, lg string) ([]dodugo.ListItem, error)
 	SearchMounts(ctx context.Context, query, lg string) ([]dodugo.Mount, error)
+	SearchQuestItems(ctx context.Context, query, lg string) ([]dodugo.ListItem, error)
+	SearchResources(ctx context.Context, query, lg string) ([]dodugo.ListItem, error)
 	SearchSets(ctx context.Context, query, lg string) ([]dodugo.ListEquipmentSet, error)
 	SearchAlmanaxEffects(ctx context.Context, query, lg string) ([]dodugo.GetMetaAlmanaxBonuses200ResponseInner, error)
 
+	// Autocomplete functions rely on a local index and return every match, best first.
+	// DofusDude search is used until the index is built, its results being limited.
+	AutocompleteAnyItems(ctx context.Context, query, lg string) ([]dodugo.GameSearch, error)
+	AutocompleteSets(ctx context.Context, query, lg string) ([]dodugo.ListEquipmentSet, error)
+	AutocompleteAlmanaxEffects(ctx context.Context, query, lg string,
+	) ([]dodugo.GetMetaAlmanaxBonuses200ResponseInner, error)
+
 	GetConsumableByID(ctx context.Context, consumableID int64, lg string) (*dodugo.Resource, error)
 	GetCosmeticByID(ctx context.Context, cosmeticID int64, lg string) (*dodugo.Weapon, error)
 	GetEquipmentByID(ctx context.Context, equipmentID int64, lg string) (*dodugo.Weapon, error)
@@ -47,22 +100,151 @@ type Service interface {
 	GetSetByID(ctx context.Context, setID int64, lg string) (*dodugo.EquipmentSet, error)
 	GetSets(ctx context.Context) ([]dodugo.ListEquipmentSet, error)
 
+	// FilterEquipments retrieves equipments by type and level range, effects included.
+	// EquipmentType_NONE and bounds which are not positive do not filter.
+	FilterEquipments(ctx context.Context, equipmentType amqp.EquipmentType, minLevel, maxLevel int64,
+		lg string) ([]dodugo.ListItem, error)
+
+	GetConsumableByQuery(ctx context.Context, query, lg string) (*dodugo.Resource, error)
 	GetCosmeticByQuery(ctx context.Context, query, lg string) (*dodugo.Weapon, error)
 	GetEquipmentByQuery(ctx context.Context, query, lg string) (*dodugo.Weapon, error)
 	GetMountByQuery(ctx context.Context, query, lg string) (*dodugo.Mount, error)
+	GetQuestItemByQuery(ctx context.Context, query, lg string) (*dodugo.Resource, error)
+	GetResourceByQuery(ctx context.Context, query, lg string) (*dodugo.Resource, error)
 	GetSetByQuery(ctx context.Context, query, lg string) (*dodugo.EquipmentSet, error)
 
 	GetAlmanaxByDate(ctx context.Context, date time.Time, language string) (*dodugo.Almanax, error)
 	GetAlmanaxByRange(ctx context.Context, daysDuration int64, language string) ([]dodugo.Almanax, error)
+	// GetAlmanaxBetweenDates retrieves the almanax of every day from start to end, both included.
+	GetAlmanaxBetweenDates(ctx context.Context, start, end time.Time, language string) ([]dodugo.Almanax, error)
 
 	ListenGameEvent(handler GameEventHandler)
+	// ListenCatalog registers a handler for catalogs retrieved to build autocomplete indexes,
+	// so that other indexes do not retrieve them again. Handlers must be registered before scheduler start.
+	ListenCatalog(handler CatalogHandler)
+	PrewarmPopularItems(ctx context.Context)
+	// RecordItemRequest counts the item requested within a tracked context towards popular items;
+	// it is meant to be called once per user request.
+	RecordItemRequest(ctx context.Context)
+	GetStatuses() map[string]string
+}
+
+// Provider retrieves raw data from one upstream.
+// A resource which does not exist is returned as a zero value without error,
+// errors are kept for upstream failures so that the next provider can be tried.
+type Provider interface {
+	GetSource() constants.Source
+	GetStatus() string
+
+	SearchAnyItems(ctx context.Context, query, lg string) ([]dodugo.GameSearch, error)
+	SearchConsumables(ctx context.Context, query, lg string) ([]dodugo.ListItem, error)
+	SearchCosmetics(ctx context.Context, query, lg string) ([]dodugo.ListItem, error)
+	SearchEquipments(ctx context.Context, query, lg string) ([]dodugo.ListItem, error)
+	SearchMounts(ctx context.Context, query, lg string) ([]dodugo.Mount, error)
+	SearchQuestItems(ctx context.Context, query, lg string) ([]dodugo.ListItem, error)
+	SearchResources(ctx context.Context, query, lg string) ([]dodugo.ListItem, error)
+	SearchSets(ctx context.Context, query, lg string) ([]dodugo.ListEquipmentSet, error)
+	SearchAlmanaxEffects(ctx context.Context, query, lg string) ([]dodugo.GetMetaAlmanaxBonuses200ResponseInner, error)
+
+	ListCatalog(ctx context.Context, lg string) (*Catalog, error)
+	ListSets(ctx context.Context, lg string) ([]dodugo.ListEquipmentSet, error)
+	ListAlmanaxEffects(ctx context.Context, lg string) ([]dodugo.GetMetaAlmanaxBonuses200ResponseInner, error)
+	// FilterEquipments filters by DofusDude type name if not empty.
+	FilterEquipments(ctx context.Context, typeName string, minLevel, maxLevel int32,
+		lg string) ([]dodugo.ListItem, error)
+
+	GetConsumableByID(ctx context.Context, consumableID int32, lg string) (*dodugo.Resource, error)
+	GetCosmeticByID(ctx context.Context, cosmeticID int32, lg string) (*dodugo.Weapon, error)
+	GetEquipmentByID(ctx context.Context, equipmentID int32, lg string) (*dodugo.Weapon, error)
+	GetMountByID(ctx context.Context, mountID int32, lg string) (*dodugo.Mount, error)
+	GetQuestItemByID(ctx context.Context, questItemID int32, lg string) (*dodugo.Resource, error)
+	GetResourceByID(ctx context.Context, resourceID int32, lg string) (*dodugo.Resource, error)
+	GetSetByID(ctx context.Context, setID int32, lg string) (*dodugo.EquipmentSet, error)
+	GetSets(ctx context.Context) ([]dodugo.ListEquipmentSet, error)
+
+	GetAlmanaxByDate(ctx context.Context, date, lg string) (*dodugo.Almanax, error)
+	GetAlmanaxByRange(ctx context.Context, daysDuration int32, lg string) ([]dodugo.Almanax, error)
+
+	GetGameVersion(ctx context.Context) (string, error)
+	IsGameSupported(game amqp.Game) bool
 }
 
 type Impl struct {
-	eventHandlers   []GameEventHandler
-	dofusDudeClient *dodugo.APIClient
-	storeService    stores.Service
-	gameRepo        games.Repository
-	httpTimeout     time.Duration
-	itemTypes       map[string]amqp.ItemType
+	eventHandlers     []GameEventHandler
+	catalogHandlers   []CatalogHandler
+	providers         map[objectType][]Provider
+	storeService      stores.Service
+	gameRepo          games.Repository
+	httpTimeout       time.Duration
+	itemTypes         map[string]amqp.ItemType
+	inFlight          singleflight.Group
+	cacheRetentions   map[objectType]time.Duration
+	notFoundRetention time.Duration
+	cacheVersions     map[amqp.Game]string
+	cacheVersionMutex sync.RWMutex
+	prewarmItemCount  int
+	requestCounts     map[itemRequest]int
+	popularItems      []itemRequest
+	popularityMutex   sync.Mutex
+	indexes           map[amqp.Game]map[string]*languageIndex
+	indexMutex        sync.RWMutex
+	indexTimeout      time.Duration
+}
+
+type autocompleteIndex[T any] struct {
+	values   []T
+	names    []string
+	trigrams map[string][]int
+	prefixes map[string][]int
+	getName  func(T) string
+}
+
+type languageIndex struct {
+	items          *autocompleteIndex[dodugo.GameSearch]
+	sets           *autocompleteIndex[dodugo.ListEquipmentSet]
+	almanaxEffects *autocompleteIndex[dodugo.GetMetaAlmanaxBonuses200ResponseInner]
+}
+
+type itemRequest struct {
+	game     amqp.Game
+	itemType amqp.ItemType
+	itemID   int64
+	language string
+}
+
+type dofusDudeProvider struct {
+	client       *dodugo.APIClient
+	breaker      breakers.CircuitBreaker
+	retries      int
+	backoff      time.Duration
+	maxBackoff   time.Duration
+	retryCounter prometheus.Counter
+}
+
+type snapshotProvider struct {
+	version  string
+	catalogs map[string]*snapshotCatalog
+}
+
+type snapshotCatalog struct {
+	equipments     map[int32]dodugo.Weapon
+	cosmetics      map[int32]dodugo.Weapon
+	consumables    map[int32]dodugo.Resource
+	questItems     map[int32]dodugo.Resource
+	resources      map[int32]dodugo.Resource
+	mounts         map[int32]dodugo.Mount
+	sets           map[int32]dodugo.EquipmentSet
+	almanaxes      map[string]dodugo.Almanax
+	almanaxBonuses []dodugo.GetMetaAlmanaxBonuses200ResponseInner
+}
+
+type sourceTrackerKey struct{}
+
+type gameKey struct{}
+
+type sourceTracker struct {
+	mutex     sync.Mutex
+	source    *constants.Source
+	stale     bool
+	requested *itemRequest
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/go-redis/cache/v9"
 	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
@@ -12,13 +13,17 @@ import (
 )
 
 func New() *Impl {
+	redisClient := redis.NewClient(&redis.Options{
+		Username: viper.GetString(constants.RedisUser),
+		Password: viper.GetString(constants.RedisPassword),
+		Addr:     viper.GetString(constants.RedisURL),
+	})
+
 	return &Impl{
+		redis:          redisClient,
+		staleRetention: viper.GetDuration(constants.RedisCacheStaleRetention),
 		cache: cache.New(&cache.Options{
-			Redis: redis.NewClient(&redis.Options{
-				Username: viper.GetString(constants.RedisUser),
-				Password: viper.GetString(constants.RedisPassword),
-				Addr:     viper.GetString(constants.RedisURL),
-			}),
+			Redis: redisClient,
 			LocalCache: cache.NewTinyLFU(
 				viper.GetInt(constants.RedisCacheSize),
 				viper.GetDuration(constants.RedisCacheRetention),
@@ -27,29 +32,86 @@ func New() *Impl {
 	}
 }
 
-func (service *Impl) Get(ctx context.Context, key string, value any) error {
+func (service *Impl) Get(ctx context.Context, key string, value any) (bool, error) {
 	var jsonValue []byte
 	err := service.cache.Get(ctx, buildKey(key), &jsonValue)
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	return json.Unmarshal(jsonValue, value)
+	var cacheEntry entry
+	err = json.Unmarshal(jsonValue, &cacheEntry)
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	if !cacheEntry.HardExpiry.IsZero() && now.After(cacheEntry.HardExpiry) {
+		service.cache.DeleteFromLocalCache(buildKey(key))
+		return false, cache.ErrCacheMiss
+	}
+
+	return now.After(cacheEntry.SoftExpiry), json.Unmarshal(cacheEntry.Value, value)
+}
+
+func (service *Impl) Set(ctx context.Context, key string, value any, retention time.Duration) error {
+	return service.set(ctx, key, value, retention, service.staleRetention)
 }
 
-func (service *Impl) Set(ctx context.Context, key string, value any) error {
+func (service *Impl) SetNotFound(ctx context.Context, key string, retention time.Duration) error {
+	return service.set(ctx, key, nil, retention, 0)
+}
+
+func (service *Impl) set(ctx context.Context, key string, value any,
+	retention, staleRetention time.Duration) error {
 	jsonValue, err := json.Marshal(value)
 	if err != nil {
 		return err
 	}
 
+	now := time.Now()
+	jsonEntry, err := json.Marshal(entry{
+		Value:      jsonValue,
+		SoftExpiry: now.Add(retention),
+		HardExpiry: now.Add(retention + staleRetention),
+	})
+	if err != nil {
+		return err
+	}
+
 	return service.cache.Set(&cache.Item{
 		Ctx:   ctx,
 		Key:   buildKey(key),
-		Value: jsonValue,
+		Value: jsonEntry,
+		TTL:   retention + staleRetention,
 	})
 }
 
+func (service *Impl) DeleteByPrefix(ctx context.Context, prefix string) error {
+	keys := make([]string, 0, deletionBatchSize)
+	iterator := service.redis.Scan(ctx, 0, buildKey(prefix)+"*", deletionBatchSize).Iterator()
+	for iterator.Next(ctx) {
+		service.cache.DeleteFromLocalCache(iterator.Val())
+		keys = append(keys, iterator.Val())
+		if len(keys) >= deletionBatchSize {
+			if err := service.redis.Unlink(ctx, keys...).Err(); err != nil {
+				return err
+			}
+			keys = keys[:0]
+		}
+	}
+
+	if err := iterator.Err(); err != nil {
+		return err
+	}
+
+	if len(keys) > 0 {
+		return service.redis.Unlink(ctx, keys...).Err()
+	}
+
+	return nil
+}
+
 func buildKey(query string) string {
 	return fmt.Sprintf("%v/%v", constants.InternalName, query)
 }
@@ -0,0 +1,65 @@
+package stores
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-redis/cache/v9"
+)
+
+func newTestStore(staleRetention time.Duration) *Impl {
+	return &Impl{
+		staleRetention: staleRetention,
+		cache: cache.New(&cache.Options{
+			LocalCache: cache.NewTinyLFU(10, time.Hour),
+		}),
+	}
+}
+
+func TestGetSoftExpiry(t *testing.T) {
+	store := newTestStore(time.Hour)
+	ctx := context.Background()
+	if err := store.Set(ctx, "key", "value", time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	var value string
+	stale, err := store.Get(ctx, "key", &value)
+	if err != nil {
+		t.Fatalf("expected stale entry to be served, got %v", err)
+	}
+	if !stale || value != "value" {
+		t.Errorf("expected stale 'value', got stale=%v '%v'", stale, value)
+	}
+}
+
+func TestGetHardExpiry(t *testing.T) {
+	store := newTestStore(time.Millisecond)
+	ctx := context.Background()
+	if err := store.Set(ctx, "key", "value", time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	var value string
+	if _, err := store.Get(ctx, "key", &value); !errors.Is(err, cache.ErrCacheMiss) {
+		t.Errorf("expected entry past its hard expiry to be missed locally, got %v", err)
+	}
+}
+
+func TestSetNotFoundExpiry(t *testing.T) {
+	store := newTestStore(time.Hour)
+	ctx := context.Background()
+	if err := store.SetNotFound(ctx, "key", time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	var value *string
+	if _, err := store.Get(ctx, "key", &value); !errors.Is(err, cache.ErrCacheMiss) {
+		t.Errorf("expected not-found entry not to be served stale, got %v", err)
+	}
+}
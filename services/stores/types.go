@@ -2,15 +2,38 @@ package stores
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"github.com/go-redis/cache/v9"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	deletionBatchSize = 500
 )
 
 type Service interface {
-	Get(ctx context.Context, key string, value any) error
-	Set(ctx context.Context, key string, value any) error
+	// Get fills value and reports whether it is stale, i.e. past its soft expiry.
+	Get(ctx context.Context, key string, value any) (bool, error)
+	// Set stores value as fresh during retention, then as stale during the stale retention.
+	Set(ctx context.Context, key string, value any, retention time.Duration) error
+	// SetNotFound stores a not-found result during retention, without being served stale afterwards.
+	SetNotFound(ctx context.Context, key string, retention time.Duration) error
+	// DeleteByPrefix removes from Redis and from the local cache every key starting with prefix.
+	DeleteByPrefix(ctx context.Context, prefix string) error
 }
 
 type Impl struct {
-	cache *cache.Cache
+	cache          *cache.Cache
+	redis          *redis.Client
+	staleRetention time.Duration
+}
+
+// entry is stored until its hard expiry, which is its soft expiry plus the stale retention.
+// The local cache tier has a single TTL, so the hard expiry is checked again on read.
+type entry struct {
+	Value      json.RawMessage `json:"value"`
+	SoftExpiry time.Time       `json:"softExpiry"`
+	HardExpiry time.Time       `json:"hardExpiry"`
 }
@@ -0,0 +1,167 @@
+package breakers
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	StateClosed State = iota
+	StateHalfOpen
+	StateOpen
+)
+
+var (
+	ErrCircuitOpen = errors.New("circuit breaker is open, upstream is considered unhealthy")
+)
+
+type State int
+
+type CircuitBreaker interface {
+	// Allow returns false if calls must fail fast.
+	Allow() bool
+	Success()
+	Failure()
+	// Release ends an allowed call whose outcome says nothing about the upstream health,
+	// such as a call canceled or throttled locally: the state and failure streak are kept.
+	Release()
+	GetState() State
+}
+
+type circuitBreaker struct {
+	mutex            sync.Mutex
+	name             string
+	state            State
+	failures         int
+	failureThreshold int
+	cooldown         time.Duration
+	openedAt         time.Time
+	probing          bool
+	stateGauge       prometheus.Gauge
+	rejectionCounter prometheus.Counter
+}
+
+// New returns a circuit breaker which opens after failureThreshold consecutive
+// failures, then lets one probe call through once cooldown is elapsed.
+func New(name string, failureThreshold int, cooldown time.Duration) CircuitBreaker {
+	labels := prometheus.Labels{constants.MetricLabelSource: name}
+	breaker := circuitBreaker{
+		name:             name,
+		state:            StateClosed,
+		failureThreshold: failureThreshold,
+		cooldown:         cooldown,
+		stateGauge: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace:   constants.MetricNamespace,
+			Name:        "circuit_breaker_state",
+			Help:        "Circuit breaker state: 0 for closed, 1 for half-open, 2 for open.",
+			ConstLabels: labels,
+		}),
+		rejectionCounter: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   constants.MetricNamespace,
+			Name:        "circuit_breaker_rejections_total",
+			Help:        "Number of calls rejected because the circuit breaker is open.",
+			ConstLabels: labels,
+		}),
+	}
+
+	for _, collector := range []prometheus.Collector{breaker.stateGauge, breaker.rejectionCounter} {
+		if err := prometheus.Register(collector); err != nil {
+			log.Warn().Err(err).
+				Str(constants.LogSource, name).
+				Msgf("Cannot register circuit breaker metric, continuing without it...")
+		}
+	}
+
+	return &breaker
+}
+
+func (breaker *circuitBreaker) Allow() bool {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	switch breaker.state {
+	case StateClosed:
+		return true
+	case StateOpen:
+		if time.Since(breaker.openedAt) < breaker.cooldown {
+			breaker.rejectionCounter.Inc()
+			return false
+		}
+		breaker.setState(StateHalfOpen)
+		breaker.probing = true
+		return true
+	case StateHalfOpen:
+		// Only one probe call at a time while half-open.
+		if breaker.probing {
+			breaker.rejectionCounter.Inc()
+			return false
+		}
+		breaker.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+func (breaker *circuitBreaker) Success() {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	breaker.failures = 0
+	breaker.probing = false
+	if breaker.state != StateClosed {
+		log.Info().Str(constants.LogSource, breaker.name).Msgf("Circuit breaker closed")
+		breaker.setState(StateClosed)
+	}
+}
+
+func (breaker *circuitBreaker) Failure() {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	breaker.failures++
+	breaker.probing = false
+	if breaker.state == StateHalfOpen ||
+		(breaker.state == StateClosed && breaker.failures >= breaker.failureThreshold) {
+		log.Warn().Str(constants.LogSource, breaker.name).
+			Msgf("Circuit breaker opened after %v consecutive failures", breaker.failures)
+		breaker.openedAt = time.Now()
+		breaker.setState(StateOpen)
+	}
+}
+
+func (breaker *circuitBreaker) Release() {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	breaker.probing = false
+}
+
+func (breaker *circuitBreaker) GetState() State {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+	return breaker.state
+}
+
+func (breaker *circuitBreaker) setState(state State) {
+	breaker.state = state
+	breaker.stateGauge.Set(float64(state))
+}
+
+func (state State) String() string {
+	switch state {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half-open"
+	case StateOpen:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
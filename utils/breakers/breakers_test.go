@@ -0,0 +1,108 @@
+package breakers
+
+import (
+	"testing"
+	"time"
+)
+
+func failTimes(breaker CircuitBreaker, times int) {
+	for range times {
+		breaker.Allow()
+		breaker.Failure()
+	}
+}
+
+func TestCircuitBreakerOpensAfterThreshold(t *testing.T) {
+	breaker := New("test-threshold", 3, time.Hour)
+
+	failTimes(breaker, 2)
+	if state := breaker.GetState(); state != StateClosed {
+		t.Fatalf("expected closed breaker below threshold, got %v", state)
+	}
+
+	failTimes(breaker, 1)
+	if state := breaker.GetState(); state != StateOpen {
+		t.Fatalf("expected open breaker at threshold, got %v", state)
+	}
+
+	if breaker.Allow() {
+		t.Fatal("expected calls to fail fast during cooldown")
+	}
+}
+
+func TestCircuitBreakerSuccessResetsFailures(t *testing.T) {
+	breaker := New("test-success", 3, time.Hour)
+
+	failTimes(breaker, 2)
+	breaker.Allow()
+	breaker.Success()
+	failTimes(breaker, 2)
+
+	if state := breaker.GetState(); state != StateClosed {
+		t.Fatalf("expected failure streak to be reset by a success, got %v", state)
+	}
+}
+
+func TestCircuitBreakerReleaseKeepsFailures(t *testing.T) {
+	breaker := New("test-release-closed", 3, time.Hour)
+
+	failTimes(breaker, 2)
+	breaker.Allow()
+	breaker.Release()
+	failTimes(breaker, 1)
+
+	if state := breaker.GetState(); state != StateOpen {
+		t.Fatalf("expected failure streak to be kept by a release, got %v", state)
+	}
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	tests := []struct {
+		name     string
+		outcome  func(breaker CircuitBreaker)
+		expected State
+	}{
+		{
+			name:     "successful probe closes the breaker",
+			outcome:  func(breaker CircuitBreaker) { breaker.Success() },
+			expected: StateClosed,
+		},
+		{
+			name:     "failed probe opens the breaker again",
+			outcome:  func(breaker CircuitBreaker) { breaker.Failure() },
+			expected: StateOpen,
+		},
+		{
+			name:     "released probe keeps the breaker half-open",
+			outcome:  func(breaker CircuitBreaker) { breaker.Release() },
+			expected: StateHalfOpen,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// No cooldown: the first call after opening is a probe.
+			breaker := New("test-half-open", 1, 0)
+			failTimes(breaker, 1)
+
+			if !breaker.Allow() {
+				t.Fatal("expected a probe call to be allowed")
+			}
+			if state := breaker.GetState(); state != StateHalfOpen {
+				t.Fatalf("expected half-open breaker while probing, got %v", state)
+			}
+			if breaker.Allow() {
+				t.Fatal("expected a single probe call at a time")
+			}
+
+			test.outcome(breaker)
+			if state := breaker.GetState(); state != test.expected {
+				t.Fatalf("expected %v breaker, got %v", test.expected, state)
+			}
+			// Without cooldown, the next call is always let through, as a probe if not closed.
+			if !breaker.Allow() {
+				t.Fatal("expected next call to be allowed")
+			}
+		})
+	}
+}
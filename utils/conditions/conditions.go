@@ -0,0 +1,130 @@
+package conditions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dofusdude/dodugo"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+)
+
+type evaluator struct {
+	values    map[string]int64
+	unchecked []constants.Condition
+}
+
+// Evaluate walks the conditions tree against the character profile.
+// Elements are matched by name, in the DofusDude language the conditions have been retrieved with.
+func Evaluate(node *dodugo.ConditionNode, profile constants.CharacterProfile,
+	lg string) constants.ConditionReport {
+	values := make(map[string]int64)
+	for _, characteristic := range profile.Characteristics {
+		values[strings.ToLower(characteristic.Name)] = characteristic.Min
+	}
+	if levelName, found := constants.GetLevelElementNames()[lg]; found && profile.Level > 0 {
+		values[levelName] = profile.Level
+	}
+	if alignmentName, found := constants.GetAlignmentElementNames()[lg]; found {
+		values[alignmentName] = profile.Alignment
+	}
+
+	evaluator := evaluator{
+		values:    values,
+		unchecked: make([]constants.Condition, 0),
+	}
+	failures, _ := evaluator.evaluate(node)
+
+	return constants.ConditionReport{
+		Equippable: len(failures) == 0,
+		Failures:   failures,
+		Unchecked:  evaluator.unchecked,
+	}
+}
+
+// EvaluateNullable evaluates the conditions if any, an item without conditions is equippable.
+func EvaluateNullable(conditions dodugo.NullableConditionNode, profile constants.CharacterProfile,
+	lg string) constants.ConditionReport {
+	if !conditions.IsSet() {
+		return Evaluate(nil, profile, lg)
+	}
+
+	return Evaluate(conditions.Get(), profile, lg)
+}
+
+// evaluate returns the leaf conditions which make the node fail, none if the node is met,
+// and whether the node has been checked: a node relying on unchecked leaves is not considered failed.
+// Only a checked child is enough for an or relation; its other children are still evaluated otherwise,
+// so that their unchecked leaves are reported.
+func (evaluator *evaluator) evaluate(node *dodugo.ConditionNode) ([]constants.Condition, bool) {
+	failures := make([]constants.Condition, 0)
+	if node == nil {
+		return failures, true
+	}
+
+	if node.ConditionLeaf != nil {
+		condition := mapCondition(node.ConditionLeaf.Condition)
+		met, checked := evaluator.evaluateLeaf(condition)
+		if !checked {
+			evaluator.unchecked = append(evaluator.unchecked, condition)
+		} else if !met {
+			failures = append(failures, condition)
+		}
+
+		return failures, checked
+	}
+
+	checked := true
+	if node.ConditionRelation != nil {
+		isOr := strings.EqualFold(node.ConditionRelation.GetRelation(), constants.ConditionRelationOr)
+		var uncheckedChild bool
+		for _, child := range node.ConditionRelation.GetChildren() {
+			childFailures, childChecked := evaluator.evaluate(child)
+			if isOr && len(childFailures) == 0 {
+				if childChecked {
+					return make([]constants.Condition, 0), true
+				}
+				uncheckedChild = true
+			}
+
+			failures = append(failures, childFailures...)
+			checked = checked && childChecked
+		}
+
+		if isOr && uncheckedChild {
+			return make([]constants.Condition, 0), false
+		}
+	}
+
+	return failures, checked
+}
+
+// evaluateLeaf returns whether the condition is met and whether it could be checked at all.
+func (evaluator *evaluator) evaluateLeaf(condition constants.Condition) (bool, bool) {
+	value, found := evaluator.values[strings.ToLower(condition.ElementName)]
+	if !found {
+		return true, false
+	}
+
+	switch condition.Operator {
+	case constants.ConditionOperatorGreater:
+		return value > condition.Value, true
+	case constants.ConditionOperatorLower:
+		return value < condition.Value, true
+	case constants.ConditionOperatorEqual:
+		return value == condition.Value, true
+	case constants.ConditionOperatorNotEqual:
+		return value != condition.Value, true
+	default:
+		return true, false
+	}
+}
+
+func mapCondition(condition *dodugo.Condition) constants.Condition {
+	element := condition.GetElement()
+	return constants.Condition{
+		ElementID:   fmt.Sprintf("%v", element.GetId()),
+		ElementName: element.GetName(),
+		Operator:    condition.GetOperator(),
+		Value:       int64(condition.GetIntValue()),
+	}
+}
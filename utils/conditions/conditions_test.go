@@ -0,0 +1,157 @@
+package conditions
+
+import (
+	"testing"
+
+	"github.com/dofusdude/dodugo"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+)
+
+func newTestLeaf(elementName, operator string, value int32) *dodugo.ConditionNode {
+	return &dodugo.ConditionNode{
+		ConditionLeaf: &dodugo.ConditionLeaf{
+			Condition: &dodugo.Condition{
+				Operator: dodugo.PtrString(operator),
+				IntValue: dodugo.PtrInt32(value),
+				Element: &dodugo.TranslatedId{
+					Id:   dodugo.PtrInt32(1),
+					Name: dodugo.PtrString(elementName),
+				},
+			},
+		},
+	}
+}
+
+func newTestRelation(relation string, children ...*dodugo.ConditionNode) *dodugo.ConditionNode {
+	return &dodugo.ConditionNode{
+		ConditionRelation: &dodugo.ConditionRelation{
+			Relation: dodugo.PtrString(relation),
+			Children: children,
+		},
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	profile := constants.CharacterProfile{
+		Level:     150,
+		Alignment: 1,
+		Characteristics: []constants.Characteristic{
+			{Name: "Force", Min: 300, Max: 300},
+			{Name: "PA", Min: 11, Max: 11},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		node       *dodugo.ConditionNode
+		equippable bool
+		failures   int
+		unchecked  int
+	}{
+		{
+			name:       "no condition",
+			equippable: true,
+		},
+		{
+			name:       "met characteristic, case ignored",
+			node:       newTestLeaf("FORCE", constants.ConditionOperatorGreater, 200),
+			equippable: true,
+		},
+		{
+			name:     "unmet characteristic",
+			node:     newTestLeaf("PA", constants.ConditionOperatorLower, 11),
+			failures: 1,
+		},
+		{
+			name: "level and alignment in the conditions language",
+			node: newTestRelation(constants.ConditionRelationAnd,
+				newTestLeaf("Niveau", ">", 100),
+				newTestLeaf("Alignement", "=", 1)),
+			equippable: true,
+		},
+		{
+			name:       "unknown element is considered met",
+			node:       newTestLeaf("Sagesse", constants.ConditionOperatorGreater, 1000),
+			equippable: true,
+			unchecked:  1,
+		},
+		{
+			name: "every child of an and relation is reported",
+			node: newTestRelation(constants.ConditionRelationAnd,
+				newTestLeaf("Force", "<", 100),
+				newTestLeaf("PA", "!", 11)),
+			failures: 2,
+		},
+		{
+			name: "or relation is met by a single checked child",
+			node: newTestRelation(constants.ConditionRelationOr,
+				newTestLeaf("Force", "<", 100),
+				newTestLeaf("PA", "=", 11)),
+			equippable: true,
+		},
+		{
+			name: "or relation fails if every child fails",
+			node: newTestRelation(constants.ConditionRelationOr,
+				newTestLeaf("Force", "<", 100),
+				newTestLeaf("PA", ">", 12)),
+			failures: 2,
+		},
+		{
+			name: "unchecked child does not satisfy an or relation",
+			node: newTestRelation(constants.ConditionRelationOr,
+				newTestLeaf("Sagesse", ">", 1000),
+				newTestLeaf("Chance", ">", 10)),
+			equippable: true,
+			unchecked:  2,
+		},
+		{
+			name: "or relation met by a child following an unchecked one",
+			node: newTestRelation(constants.ConditionRelationOr,
+				newTestLeaf("Sagesse", ">", 1000),
+				newTestLeaf("PA", "=", 11)),
+			equippable: true,
+			unchecked:  1,
+		},
+		{
+			name: "or relation with unchecked and failed children is not failed",
+			node: newTestRelation(constants.ConditionRelationAnd,
+				newTestRelation(constants.ConditionRelationOr,
+					newTestLeaf("Sagesse", ">", 1000),
+					newTestLeaf("Force", "<", 100)),
+				newTestLeaf("PA", ">", 12)),
+			failures:  1,
+			unchecked: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			report := Evaluate(test.node, profile, "fr")
+			if report.Equippable != test.equippable || len(report.Failures) != test.failures ||
+				len(report.Unchecked) != test.unchecked {
+				t.Errorf("expected equippable %v with %v failures and %v unchecked, got %+v",
+					test.equippable, test.failures, test.unchecked, report)
+			}
+		})
+	}
+}
+
+func TestEvaluateWithoutLevel(t *testing.T) {
+	report := Evaluate(newTestLeaf("Niveau", constants.ConditionOperatorGreater, 100),
+		constants.CharacterProfile{}, "fr")
+	if !report.Equippable || len(report.Unchecked) != 1 {
+		t.Errorf("expected level condition to be unchecked without a level, got %+v", report)
+	}
+}
+
+func TestEvaluateNullable(t *testing.T) {
+	profile := constants.CharacterProfile{Level: 50}
+	if report := EvaluateNullable(dodugo.NullableConditionNode{}, profile, "fr"); !report.Equippable {
+		t.Errorf("expected an item without conditions to be equippable, got %+v", report)
+	}
+
+	conditions := dodugo.NewNullableConditionNode(newTestLeaf("Niveau", constants.ConditionOperatorGreater, 100))
+	if report := EvaluateNullable(*conditions, profile, "fr"); report.Equippable || len(report.Failures) != 1 {
+		t.Errorf("expected level condition to fail, got %+v", report)
+	}
+}
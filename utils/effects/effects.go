@@ -0,0 +1,185 @@
+package effects
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dofusdude/dodugo"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+)
+
+// Parse extracts the values, the element and the normalized characteristic of an effect.
+func Parse(effect dodugo.Effect) constants.Effect {
+	return parse(effect, constants.GetEffectDefinitions())
+}
+
+// Sum totals the effects by effect type, maluses being merged with their bonus.
+// Weapon lines and effects without numeric value (such as titles or emotes) are left out.
+func Sum(effectLists ...[]dodugo.Effect) []constants.Characteristic {
+	return sum(false, effectLists...)
+}
+
+// SumWeaponLines totals the weapon lines (damages, heals, steals) by effect type.
+func SumWeaponLines(effectLists ...[]dodugo.Effect) []constants.Characteristic {
+	return sum(true, effectLists...)
+}
+
+func sum(weaponLines bool, effectLists ...[]dodugo.Effect) []constants.Characteristic {
+	definitions := constants.GetEffectDefinitions()
+	canonicalIDs := getCanonicalIDs(definitions)
+	totals := make(map[int32]*constants.Characteristic)
+	for _, effects := range effectLists {
+		for _, effect := range effects {
+			structured := parse(effect, definitions)
+			if structured.IsWeaponLine != weaponLines || effect.GetIgnoreIntMin() {
+				continue
+			}
+
+			effectType := effect.GetType()
+			effectID, found := canonicalIDs[structured.Key]
+			if !found {
+				effectID = effectType.GetId()
+			}
+
+			total, found := totals[effectID]
+			if !found {
+				total = &constants.Characteristic{
+					EffectID: effectID,
+					Key:      structured.Key,
+					Element:  structured.Element,
+				}
+				totals[effectID] = total
+			}
+
+			// Maluses are named after the bonus as soon as it is encountered.
+			if total.Name == "" || effectType.GetId() == effectID {
+				total.Name = effectType.GetName()
+			}
+			total.Min += structured.Min
+			total.Max += structured.Max
+		}
+	}
+
+	characteristics := make([]constants.Characteristic, 0, len(totals))
+	for _, total := range totals {
+		characteristics = append(characteristics, *total)
+	}
+
+	return sortCharacteristics(characteristics)
+}
+
+// Diff returns the characteristics to reach target from origin; unchanged ones are left out.
+func Diff(origin, target []constants.Characteristic) []constants.Characteristic {
+	deltas := make(map[int32]constants.Characteristic)
+	for _, characteristic := range target {
+		deltas[characteristic.EffectID] = characteristic
+	}
+
+	for _, characteristic := range origin {
+		delta, found := deltas[characteristic.EffectID]
+		if !found {
+			delta = characteristic
+			delta.Min, delta.Max = 0, 0
+		}
+
+		delta.Min -= characteristic.Min
+		delta.Max -= characteristic.Max
+		deltas[characteristic.EffectID] = delta
+	}
+
+	characteristics := make([]constants.Characteristic, 0, len(deltas))
+	for _, delta := range deltas {
+		if delta.Min != 0 || delta.Max != 0 {
+			characteristics = append(characteristics, delta)
+		}
+	}
+
+	return sortCharacteristics(characteristics)
+}
+
+// Merge totals characteristics by effect type.
+func Merge(characteristicLists ...[]constants.Characteristic) []constants.Characteristic {
+	totals := make(map[int32]constants.Characteristic)
+	for _, characteristics := range characteristicLists {
+		for _, characteristic := range characteristics {
+			total, found := totals[characteristic.EffectID]
+			if !found {
+				totals[characteristic.EffectID] = characteristic
+				continue
+			}
+
+			total.Min += characteristic.Min
+			total.Max += characteristic.Max
+			totals[characteristic.EffectID] = total
+		}
+	}
+
+	merged := make([]constants.Characteristic, 0, len(totals))
+	for _, total := range totals {
+		merged = append(merged, total)
+	}
+
+	return sortCharacteristics(merged)
+}
+
+func parse(effect dodugo.Effect, definitions map[int32]constants.EffectDefinition) constants.Effect {
+	effectType := effect.GetType()
+	structured := constants.Effect{
+		ID:           fmt.Sprintf("%v", effectType.GetId()),
+		Label:        effect.GetFormatted(),
+		Category:     constants.EffectCategoryCharacteristic,
+		IsWeaponLine: effectType.GetIsActive(),
+	}
+
+	if effect.GetIgnoreIntMin() {
+		structured.Category = constants.EffectCategoryOther
+		return structured
+	}
+
+	structured.Min, structured.Max = getValues(effect)
+	definition, found := definitions[effectType.GetId()]
+	if !found {
+		if structured.IsWeaponLine {
+			structured.Category = constants.EffectCategoryOther
+		}
+		return structured
+	}
+
+	if definition.Negative {
+		structured.Min, structured.Max = -structured.Max, -structured.Min
+	}
+	structured.Key = definition.Key
+	structured.Category = definition.Category
+	structured.Element = definition.Element
+	return structured
+}
+
+// getCanonicalIDs returns the bonus effect type ID of each normalized characteristic.
+func getCanonicalIDs(definitions map[int32]constants.EffectDefinition) map[string]int32 {
+	canonicalIDs := make(map[string]int32)
+	for effectID, definition := range definitions {
+		if definition.Key != "" && !definition.Negative {
+			canonicalIDs[definition.Key] = effectID
+		}
+	}
+
+	return canonicalIDs
+}
+
+// getValues returns the effect range; a single value is returned as both bounds.
+func getValues(effect dodugo.Effect) (int64, int64) {
+	minimum := int64(effect.GetIntMinimum())
+	if effect.GetIgnoreIntMax() || effect.GetIntMaximum() == 0 {
+		return minimum, minimum
+	}
+
+	return minimum, max(minimum, int64(effect.GetIntMaximum()))
+}
+
+func sortCharacteristics(characteristics []constants.Characteristic) []constants.Characteristic {
+	sort.Slice(characteristics, func(i, j int) bool {
+		return characteristics[i].EffectID < characteristics[j].EffectID
+	})
+
+	return characteristics
+}
@@ -0,0 +1,140 @@
+package effects
+
+import (
+	"testing"
+
+	"github.com/dofusdude/dodugo"
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+)
+
+func newTestEffect(effectID int32, name string, minimum, maximum int32) dodugo.Effect {
+	return dodugo.Effect{
+		IntMinimum: dodugo.PtrInt32(minimum),
+		IntMaximum: dodugo.PtrInt32(maximum),
+		Type: &dodugo.EffectType{
+			Id:   dodugo.PtrInt32(effectID),
+			Name: dodugo.PtrString(name),
+		},
+	}
+}
+
+func checkCharacteristics(t *testing.T, expected, characteristics []constants.Characteristic) {
+	t.Helper()
+	if len(characteristics) != len(expected) {
+		t.Fatalf("expected %+v, got %+v", expected, characteristics)
+	}
+	for i, characteristic := range characteristics {
+		if characteristic != expected[i] {
+			t.Errorf("expected %+v at position %v, got %+v", expected[i], i, characteristic)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	weaponLine := newTestEffect(99, "dommages Feu", 10, 15)
+	weaponLine.Type.IsActive = dodugo.PtrBool(true)
+	unknownWeaponLine := newTestEffect(5001, "Inconnu", 1, 2)
+	unknownWeaponLine.Type.IsActive = dodugo.PtrBool(true)
+	title := newTestEffect(999, "Titre", 0, 0)
+	title.IgnoreIntMin = dodugo.PtrBool(true)
+
+	tests := []struct {
+		name     string
+		effect   dodugo.Effect
+		expected constants.Effect
+	}{
+		{
+			name:   "characteristic with a single value",
+			effect: newTestEffect(118, "Force", 20, 0),
+			expected: constants.Effect{ID: "118", Min: 20, Max: 20, Key: "strength",
+				Element: constants.EffectElementEarth, Category: constants.EffectCategoryCharacteristic},
+		},
+		{
+			name:   "malus range is negated",
+			effect: newTestEffect(168, "PA", 1, 2),
+			expected: constants.Effect{ID: "168", Min: -2, Max: -1, Key: "ap",
+				Category: constants.EffectCategoryCharacteristic},
+		},
+		{
+			name:   "weapon line",
+			effect: weaponLine,
+			expected: constants.Effect{ID: "99", Min: 10, Max: 15, Element: constants.EffectElementFire,
+				Category: constants.EffectCategoryDamage, IsWeaponLine: true},
+		},
+		{
+			name:   "unknown weapon line",
+			effect: unknownWeaponLine,
+			expected: constants.Effect{ID: "5001", Min: 1, Max: 2,
+				Category: constants.EffectCategoryOther, IsWeaponLine: true},
+		},
+		{
+			name:     "effect without value",
+			effect:   title,
+			expected: constants.Effect{ID: "999", Category: constants.EffectCategoryOther},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if effect := Parse(test.effect); effect != test.expected {
+				t.Errorf("expected %+v, got %+v", test.expected, effect)
+			}
+		})
+	}
+}
+
+func TestSum(t *testing.T) {
+	weaponLine := newTestEffect(97, "dommages Terre", 10, 15)
+	weaponLine.Type.IsActive = dodugo.PtrBool(true)
+	title := newTestEffect(999, "Titre", 0, 0)
+	title.IgnoreIntMin = dodugo.PtrBool(true)
+
+	characteristics := Sum(
+		[]dodugo.Effect{
+			newTestEffect(152, "Chance", 5, 0),
+			newTestEffect(118, "Force", 20, 30),
+			weaponLine,
+			title,
+		},
+		[]dodugo.Effect{
+			newTestEffect(123, "Chance", 40, 0),
+			newTestEffect(5000, "Inconnu", 3, 0),
+		},
+	)
+
+	checkCharacteristics(t, []constants.Characteristic{
+		{EffectID: 118, Name: "Force", Key: "strength", Element: constants.EffectElementEarth, Min: 20, Max: 30},
+		{EffectID: 123, Name: "Chance", Key: "chance", Element: constants.EffectElementWater, Min: 35, Max: 35},
+		{EffectID: 5000, Name: "Inconnu", Min: 3, Max: 3},
+	}, characteristics)
+}
+
+func TestSumWeaponLines(t *testing.T) {
+	weaponLine := newTestEffect(97, "dommages Terre", 10, 15)
+	weaponLine.Type.IsActive = dodugo.PtrBool(true)
+
+	characteristics := SumWeaponLines([]dodugo.Effect{weaponLine, newTestEffect(118, "Force", 20, 30)})
+
+	checkCharacteristics(t, []constants.Characteristic{
+		{EffectID: 97, Name: "dommages Terre", Element: constants.EffectElementEarth, Min: 10, Max: 15},
+	}, characteristics)
+}
+
+func TestDiff(t *testing.T) {
+	origin := []constants.Characteristic{
+		{EffectID: 111, Name: "PA", Key: "ap", Min: 1, Max: 1},
+		{EffectID: 118, Name: "Force", Key: "strength", Min: 20, Max: 20},
+		{EffectID: 125, Name: "Vitalité", Key: "vitality", Min: 100, Max: 100},
+	}
+	target := []constants.Characteristic{
+		{EffectID: 111, Name: "PA", Key: "ap", Min: 1, Max: 1},
+		{EffectID: 118, Name: "Force", Key: "strength", Min: 50, Max: 50},
+		{EffectID: 128, Name: "PM", Key: "mp", Min: 1, Max: 1},
+	}
+
+	checkCharacteristics(t, []constants.Characteristic{
+		{EffectID: 118, Name: "Force", Key: "strength", Min: 30, Max: 30},
+		{EffectID: 125, Name: "Vitalité", Key: "vitality", Min: -100, Max: -100},
+		{EffectID: 128, Name: "PM", Key: "mp", Min: 1, Max: 1},
+	}, Diff(origin, target))
+}
@@ -2,6 +2,7 @@ package insights
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -11,6 +12,8 @@ import (
 )
 
 type Probes interface {
+	// AddStatusFuncs adds component statuses shown on /ready, without impacting readiness.
+	AddStatusFuncs(statusFuncs ...StatusFunc)
 	ListenAndServe()
 	Shutdown()
 }
@@ -18,10 +21,13 @@ type Probes interface {
 type probes struct {
 	server       *http.Server
 	isReadyFuncs []IsReadyFunc
+	statusFuncs  []StatusFunc
 }
 
 type IsReadyFunc func() bool
 
+type StatusFunc func() map[string]string
+
 func NewProbes(isReadyFuncs ...IsReadyFunc) Probes {
 	impl := probes{
 		isReadyFuncs: isReadyFuncs,
@@ -39,6 +45,10 @@ func NewProbes(isReadyFuncs ...IsReadyFunc) Probes {
 	return &impl
 }
 
+func (probes *probes) AddStatusFuncs(statusFuncs ...StatusFunc) {
+	probes.statusFuncs = append(probes.statusFuncs, statusFuncs...)
+}
+
 func (probes *probes) ListenAndServe() {
 	go func() {
 		log.Info().Msgf("Exposing Probes...")
@@ -68,11 +78,23 @@ func (probes *probes) ready(w http.ResponseWriter, _ *http.Request) {
 		isReady = isReady && checkReadiness(isReadyFunc)
 	}
 
+	statuses := make(map[string]string)
+	for _, statusFunc := range probes.statusFuncs {
+		for component, status := range statusFunc() {
+			statuses[component] = status
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
 	if isReady {
 		w.WriteHeader(http.StatusOK)
 	} else {
 		w.WriteHeader(http.StatusServiceUnavailable)
 	}
+
+	if err := json.NewEncoder(w).Encode(statuses); err != nil {
+		log.Error().Err(err).Msgf("Cannot write component statuses")
+	}
 }
 
 //nolint:nonamedreturns // Can't avoid it, unfortunately. It is much way safer like that.
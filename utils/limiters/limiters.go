@@ -0,0 +1,133 @@
+package limiters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/kaellybot/kaelly-encyclopedia/models/constants"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrUpstreamBusy = errors.New("upstream busy, outbound rate limit is exceeded")
+)
+
+type transport struct {
+	next             http.RoundTripper
+	mutex            sync.Mutex
+	tokens           float64
+	lastRefill       time.Time
+	ratePerSecond    float64
+	burst            float64
+	maxWait          time.Duration
+	queueGauge       prometheus.Gauge
+	rejectionCounter prometheus.Counter
+}
+
+// NewTransport returns a round tripper throttled by a token bucket: requests exceeding
+// the budget wait up to maxWait for a token, then fail with ErrUpstreamBusy.
+// A request whose context ends while waiting fails with ErrUpstreamBusy wrapping the context error.
+// The limiter is disabled if ratePerSecond is not positive.
+func NewTransport(name string, next http.RoundTripper, ratePerSecond float64, burst int,
+	maxWait time.Duration) http.RoundTripper {
+	if ratePerSecond <= 0 {
+		return next
+	}
+
+	labels := prometheus.Labels{constants.MetricLabelSource: name}
+	limiter := transport{
+		next:          next,
+		tokens:        float64(burst),
+		lastRefill:    time.Now(),
+		ratePerSecond: ratePerSecond,
+		burst:         float64(burst),
+		maxWait:       maxWait,
+		queueGauge: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace:   constants.MetricNamespace,
+			Name:        "rate_limiter_queue_depth",
+			Help:        "Number of outbound requests waiting for the rate limiter.",
+			ConstLabels: labels,
+		}),
+		rejectionCounter: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   constants.MetricNamespace,
+			Name:        "rate_limiter_rejections_total",
+			Help:        "Number of outbound requests rejected by the rate limiter.",
+			ConstLabels: labels,
+		}),
+	}
+
+	for _, collector := range []prometheus.Collector{limiter.queueGauge, limiter.rejectionCounter} {
+		if err := prometheus.Register(collector); err != nil {
+			log.Warn().Err(err).
+				Str(constants.LogSource, name).
+				Msgf("Cannot register rate limiter metric, continuing without it...")
+		}
+	}
+
+	return &limiter
+}
+
+func (limiter *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := limiter.wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	return limiter.next.RoundTrip(req)
+}
+
+func (limiter *transport) wait(ctx context.Context) error {
+	delay, reserved := limiter.reserve()
+	if !reserved {
+		limiter.rejectionCounter.Inc()
+		return ErrUpstreamBusy
+	}
+
+	if delay <= 0 {
+		return nil
+	}
+
+	limiter.queueGauge.Inc()
+	defer limiter.queueGauge.Dec()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		limiter.cancelReservation()
+		return fmt.Errorf("%w: %w", ErrUpstreamBusy, ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve takes a token, possibly in advance: the returned delay is the time to wait
+// before the token is really available. No token is taken if the delay exceeds maxWait.
+func (limiter *transport) reserve() (time.Duration, bool) {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	now := time.Now()
+	limiter.tokens = min(limiter.burst, limiter.tokens+now.Sub(limiter.lastRefill).Seconds()*limiter.ratePerSecond)
+	limiter.lastRefill = now
+
+	missingTokens := 1 - limiter.tokens
+	delay := time.Duration(missingTokens / limiter.ratePerSecond * float64(time.Second))
+	if delay > limiter.maxWait {
+		return 0, false
+	}
+
+	limiter.tokens--
+	return delay, true
+}
+
+func (limiter *transport) cancelReservation() {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+	limiter.tokens = min(limiter.burst, limiter.tokens+1)
+}
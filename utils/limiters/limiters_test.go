@@ -0,0 +1,104 @@
+package limiters
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func newTestTransport(t *testing.T, name string, ratePerSecond float64, burst int,
+	maxWait time.Duration) (http.RoundTripper, *int) {
+	t.Helper()
+	calls := 0
+	next := roundTripperFunc(func(_ *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+
+	return NewTransport(name, next, ratePerSecond, burst, maxWait), &calls
+}
+
+func newTestRequest(ctx context.Context) *http.Request {
+	return httptest.NewRequest(http.MethodGet, "http://localhost", nil).WithContext(ctx)
+}
+
+func TestTransportDisabled(t *testing.T) {
+	limiter, _ := newTestTransport(t, "test-disabled", 0, 1, 0)
+	if _, ok := limiter.(*transport); ok {
+		t.Fatal("expected the next round tripper when rate is not positive")
+	}
+}
+
+func TestTransportBurst(t *testing.T) {
+	limiter, calls := newTestTransport(t, "test-burst", 1, 2, 0)
+
+	for range 2 {
+		if _, err := limiter.RoundTrip(newTestRequest(context.Background())); err != nil {
+			t.Fatalf("expected requests within burst to pass, got %v", err)
+		}
+	}
+
+	if _, err := limiter.RoundTrip(newTestRequest(context.Background())); !errors.Is(err, ErrUpstreamBusy) {
+		t.Fatalf("expected ErrUpstreamBusy once the burst is spent, got %v", err)
+	}
+	if *calls != 2 {
+		t.Errorf("expected rejected request not to reach upstream, got %v calls", *calls)
+	}
+}
+
+func TestTransportWait(t *testing.T) {
+	limiter, calls := newTestTransport(t, "test-wait", 100, 1, time.Second)
+
+	for range 2 {
+		if _, err := limiter.RoundTrip(newTestRequest(context.Background())); err != nil {
+			t.Fatalf("expected request to wait for a token, got %v", err)
+		}
+	}
+	if *calls != 2 {
+		t.Errorf("expected 2 calls, got %v", *calls)
+	}
+}
+
+func TestTransportCanceledWaitReleasesToken(t *testing.T) {
+	limiter, _ := newTestTransport(t, "test-cancel", 1, 1, time.Hour)
+	if _, err := limiter.RoundTrip(newTestRequest(context.Background())); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := limiter.RoundTrip(newTestRequest(ctx)); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	tokens := limiter.(*transport).tokens
+	if tokens < -0.5 {
+		t.Errorf("expected the canceled reservation to be given back, got %v tokens", tokens)
+	}
+}
+
+func TestTransportDeadlineWhileWaiting(t *testing.T) {
+	limiter, calls := newTestTransport(t, "test-deadline", 1, 1, time.Hour)
+	if _, err := limiter.RoundTrip(newTestRequest(context.Background())); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	_, err := limiter.RoundTrip(newTestRequest(ctx))
+	if !errors.Is(err, ErrUpstreamBusy) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected ErrUpstreamBusy wrapping context.DeadlineExceeded, got %v", err)
+	}
+	if *calls != 1 {
+		t.Errorf("expected request given up while waiting not to reach upstream, got %v calls", *calls)
+	}
+}
@@ -0,0 +1,120 @@
+package rankings
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	exactMatch = iota
+	normalizedMatch
+	prefixMatch
+	distanceMatch
+)
+
+type score struct {
+	tier     int
+	distance int
+}
+
+// BestMatch returns the index of the value whose name matches the query the best, -1 if values is empty.
+// Exact names come first, then names equal once accents and case are ignored, then names starting
+// with the query, then the closest names by edit distance. Ties keep the original order.
+func BestMatch[T any](query string, values []T, getName func(T) string) int {
+	best := -1
+	var bestScore score
+	normalizedQuery := Normalize(query)
+	for i, value := range values {
+		current := rank(query, normalizedQuery, getName(value))
+		if best < 0 || current.isBetterThan(bestScore) {
+			best = i
+			bestScore = current
+		}
+	}
+
+	return best
+}
+
+// SortByMatch sorts values from the best match to the worst one, following BestMatch rules.
+func SortByMatch[T any](query string, values []T, getName func(T) string) {
+	normalizedQuery := Normalize(query)
+	scores := make([]score, len(values))
+	indexes := make([]int, len(values))
+	for i, value := range values {
+		indexes[i] = i
+		scores[i] = rank(query, normalizedQuery, getName(value))
+	}
+
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return scores[indexes[i]].isBetterThan(scores[indexes[j]])
+	})
+
+	sorted := make([]T, len(values))
+	for i, index := range indexes {
+		sorted[i] = values[index]
+	}
+	copy(values, sorted)
+}
+
+// Normalize lowercases text, removes its accents and collapses its spaces.
+func Normalize(text string) string {
+	withoutAccents, _, err := transform.String(transform.Chain(norm.NFD,
+		runes.Remove(runes.In(unicode.Mn)), norm.NFC), text)
+	if err != nil {
+		withoutAccents = text
+	}
+
+	return strings.Join(strings.Fields(strings.ToLower(withoutAccents)), " ")
+}
+
+func rank(query, normalizedQuery, name string) score {
+	if name == query {
+		return score{tier: exactMatch}
+	}
+
+	normalizedName := Normalize(name)
+	if normalizedName == normalizedQuery {
+		return score{tier: normalizedMatch}
+	}
+
+	if strings.HasPrefix(normalizedName, normalizedQuery) {
+		return score{tier: prefixMatch, distance: len([]rune(normalizedName)) - len([]rune(normalizedQuery))}
+	}
+
+	return score{tier: distanceMatch, distance: levenshtein([]rune(normalizedQuery), []rune(normalizedName))}
+}
+
+func (current score) isBetterThan(other score) bool {
+	if current.tier != other.tier {
+		return current.tier < other.tier
+	}
+
+	return current.distance < other.distance
+}
+
+func levenshtein(source, target []rune) int {
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(source); i++ {
+		current[0] = i
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(target)]
+}
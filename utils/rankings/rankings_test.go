@@ -0,0 +1,119 @@
+package rankings
+
+import (
+	"slices"
+	"testing"
+)
+
+func identity(value string) string {
+	return value
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{text: "Anneau Gelé", expected: "anneau gele"},
+		{text: "  Coiffe   du\tBouftou ", expected: "coiffe du bouftou"},
+		{text: "ÉPÉE", expected: "epee"},
+		{text: "", expected: ""},
+	}
+
+	for _, test := range tests {
+		if normalized := Normalize(test.text); normalized != test.expected {
+			t.Errorf("Normalize(%q): expected %q, got %q", test.text, test.expected, normalized)
+		}
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		source   string
+		target   string
+		expected int
+	}{
+		{source: "", target: "", expected: 0},
+		{source: "abc", target: "", expected: 3},
+		{source: "", target: "abc", expected: 3},
+		{source: "kitten", target: "sitting", expected: 3},
+		{source: "gelé", target: "gele", expected: 1},
+	}
+
+	for _, test := range tests {
+		distance := levenshtein([]rune(test.source), []rune(test.target))
+		if distance != test.expected {
+			t.Errorf("levenshtein(%q, %q): expected %v, got %v", test.source, test.target, test.expected, distance)
+		}
+	}
+}
+
+func TestBestMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		values   []string
+		expected int
+	}{
+		{
+			name:     "no value",
+			query:    "gelano",
+			values:   []string{},
+			expected: -1,
+		},
+		{
+			name:     "exact match before normalized one",
+			query:    "Gelano",
+			values:   []string{"gelano", "Gelano"},
+			expected: 1,
+		},
+		{
+			name:     "normalized match before prefix",
+			query:    "anneau gele",
+			values:   []string{"Anneau Gelé Royal", "Anneau Gelé"},
+			expected: 1,
+		},
+		{
+			name:     "shortest prefix first",
+			query:    "gel",
+			values:   []string{"Gelanneau", "Gelano", "Gel"},
+			expected: 2,
+		},
+		{
+			name:     "prefix before close name",
+			query:    "bouf",
+			values:   []string{"Bouf", "Bouftou"},
+			expected: 0,
+		},
+		{
+			name:     "closest name by edit distance",
+			query:    "gelamo",
+			values:   []string{"Dofus Emeraude", "Gelano"},
+			expected: 1,
+		},
+		{
+			name:     "ties keep original order",
+			query:    "xyz",
+			values:   []string{"abc", "def"},
+			expected: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if best := BestMatch(test.query, test.values, identity); best != test.expected {
+				t.Errorf("expected index %v, got %v", test.expected, best)
+			}
+		})
+	}
+}
+
+func TestSortByMatch(t *testing.T) {
+	values := []string{"Dofus Emeraude", "Gelanneau", "gelano", "Gelano", "Gelanos"}
+	SortByMatch("Gelano", values, identity)
+
+	expected := []string{"Gelano", "gelano", "Gelanos", "Gelanneau", "Dofus Emeraude"}
+	if !slices.Equal(values, expected) {
+		t.Errorf("expected %v, got %v", expected, values)
+	}
+}